@@ -13,6 +13,8 @@ import (
 	"github.com/v-Kaefer/Const-Software-25-02/internal/config"
 	appdb "github.com/v-Kaefer/Const-Software-25-02/internal/db"
 	httpapi "github.com/v-Kaefer/Const-Software-25-02/internal/http"
+	"github.com/v-Kaefer/Const-Software-25-02/internal/storage"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/timeimport"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
 )
@@ -30,6 +32,20 @@ func main() {
 		}
 	}
 
+	if cfg.Timer.MaxRunning > workspace.MaxTimerDuration {
+		log.Fatal("TIMER_MAX_RUNNING cannot exceed 24h")
+	}
+	defaultPolicy := workspace.HourPolicy{
+		MaxDailyHours:       cfg.HourPolicy.MaxDailyHours,
+		MaxWeeklyHours:      cfg.HourPolicy.MaxWeeklyHours,
+		DailyOvertimeHours:  cfg.HourPolicy.DailyOvertimeHours,
+		WeeklyOvertimeHours: cfg.HourPolicy.WeeklyOvertimeHours,
+		WeekendRule:         workspace.WeekendRule(cfg.HourPolicy.WeekendRule),
+	}
+	if err := workspace.ValidateHourPolicy(defaultPolicy); err != nil {
+		log.Fatalf("invalid HOURS_* policy: %v", err)
+	}
+
 	// 2) DB (GORM + pool + logger)
 	gormDB, err := appdb.Open(cfg)
 	if err != nil {
@@ -44,15 +60,35 @@ func main() {
 	// 4) Repositórios e serviços (injeção de dependências)
 	userRepo := user.NewRepo(gormDB)
 	userSvc := user.NewService(gormDB, userRepo)
-	projectSvc := workspace.NewProjectService(gormDB)
+	projectSvc := workspace.NewProjectService(gormDB).WithLifecycleRules(workspace.LifecycleRules{
+		RequireTasksDone:       cfg.ProjectLifecycle.CompleteRequiresTasksDone,
+		RequireApprovedEntries: cfg.ProjectLifecycle.CompleteRequiresApprovedEntries,
+	})
 	taskSvc := workspace.NewTaskService(gormDB)
-	timeSvc := workspace.NewTimeEntryService(gormDB)
+	timeSvc := workspace.NewTimeEntryService(gormDB).WithDefaultPolicy(defaultPolicy).WithEstimateWarningFactor(cfg.Estimates.WarningFactor)
+	timesheetSvc := workspace.NewTimesheetService(gormDB, timeSvc)
+	timerSvc := workspace.NewTimerService(gormDB, timeSvc, cfg.Timer.MaxRunning)
+	billingSvc := workspace.NewBillingService(gormDB)
+	reportSvc := workspace.NewReportService(gormDB)
+	importSvc := timeimport.NewService(gormDB, timeSvc)
+	periodSvc := workspace.NewPeriodLockService(gormDB)
+	delegationSvc := workspace.NewDelegationService(gormDB)
+	clientSvc := workspace.NewClientService(gormDB)
+	tagSvc := workspace.NewTagService(gormDB)
+	blobStore, err := newBlobStore(cfg.Storage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	attachmentSvc := workspace.NewAttachmentService(gormDB, blobStore, workspace.AttachmentLimits{
+		MaxBytes:     cfg.Storage.MaxUploadBytes,
+		AllowedTypes: cfg.Storage.AllowedMIMETypes,
+	})
 
 	// 5) Auth middleware (configuração do Cognito)
 	authMiddleware := httpapi.NewAuthMiddleware(cfg.Cognito)
 
 	// 6) HTTP router (camada de entrega, não conhece GORM)
-	router := httpapi.NewRouter(userSvc, projectSvc, taskSvc, timeSvc, authMiddleware)
+	router := httpapi.NewRouter(userSvc, projectSvc, taskSvc, timeSvc, timesheetSvc, timerSvc, billingSvc, reportSvc, importSvc, periodSvc, delegationSvc, clientSvc, tagSvc, attachmentSvc, authMiddleware)
 
 	// 7) CORS middleware
 	handler := corsMiddleware(router)
@@ -70,6 +106,10 @@ func main() {
 	}()
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	// 9) Encerramento automático de cronômetros esquecidos
+	go runTimerAutoStop(ctx, timerSvc, cfg.Timer.CheckInterval)
+
 	<-ctx.Done()
 	stop()
 
@@ -81,6 +121,47 @@ func main() {
 	_ = sqlDB.Close()
 }
 
+// runTimerAutoStop periodically stops timers running past the configured limit
+func runTimerAutoStop(ctx context.Context, timerSvc *workspace.TimerService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := timerSvc.AutoStopExpired(ctx); err != nil {
+				log.Printf("timer auto-stop: %v", err)
+			} else if n > 0 {
+				log.Printf("timer auto-stop: %d timer(s) stopped", n)
+			}
+		}
+	}
+}
+
+// newBlobStore opens the attachment storage selected by STORAGE_BACKEND
+func newBlobStore(cfg config.StorageConfig) (workspace.BlobStore, error) {
+	switch cfg.Backend {
+	case "local":
+		return storage.NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:     cfg.S3Endpoint,
+			Region:       cfg.S3Region,
+			Bucket:       cfg.S3Bucket,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			SessionToken: cfg.S3SessionToken,
+			PathStyle:    cfg.S3PathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", cfg.Backend)
+	}
+}
+
 // corsMiddleware adds CORS headers to all responses
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
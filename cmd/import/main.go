@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/internal/config"
+	appdb "github.com/v-Kaefer/Const-Software-25-02/internal/db"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/timeimport"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// Importa lançamentos de horas exportados do Toggl, Harvest ou Clockify.
+//
+//	go run ./cmd/import -source toggl -file export.csv -dry-run
+func main() {
+	sourceFlag := flag.String("source", "", "origem do arquivo: toggl, harvest ou clockify")
+	file := flag.String("file", "", "caminho do CSV exportado")
+	dryRun := flag.Bool("dry-run", false, "apenas valida e mostra o resultado, sem gravar")
+	flag.Parse()
+
+	source, err := timeimport.ParseSource(*sourceFlag)
+	if err != nil || *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	cfg := config.Load()
+	defaultPolicy := workspace.HourPolicy{
+		MaxDailyHours:       cfg.HourPolicy.MaxDailyHours,
+		MaxWeeklyHours:      cfg.HourPolicy.MaxWeeklyHours,
+		DailyOvertimeHours:  cfg.HourPolicy.DailyOvertimeHours,
+		WeeklyOvertimeHours: cfg.HourPolicy.WeeklyOvertimeHours,
+		WeekendRule:         workspace.WeekendRule(cfg.HourPolicy.WeekendRule),
+	}
+	if err := workspace.ValidateHourPolicy(defaultPolicy); err != nil {
+		log.Fatalf("invalid HOURS_* policy: %v", err)
+	}
+	gormDB, err := appdb.Open(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// mesmas regras de horas da API
+	timeSvc := workspace.NewTimeEntryService(gormDB).WithDefaultPolicy(defaultPolicy).WithEstimateWarningFactor(cfg.Estimates.WarningFactor)
+	importSvc := timeimport.NewService(gormDB, timeSvc)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	report, err := importSvc.Import(ctx, f, timeimport.Options{Source: source, DryRun: *dryRun})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, row := range report.Rows {
+		if row.Error != "" {
+			fmt.Printf("linha %d: %s\n", row.Line, row.Error)
+		}
+	}
+	mode := "importados"
+	if report.DryRun {
+		mode = "importáveis (dry run)"
+	}
+	fmt.Printf("%d linhas, %d %s, %d com erro\n", report.Total, report.Valid, mode, report.Invalid)
+	if report.Invalid > 0 {
+		os.Exit(1)
+	}
+}
@@ -75,6 +75,8 @@ type Middleware struct {
 	cacheMutex sync.RWMutex
 	cacheTime  time.Time
 	skipAuth   bool // For testing purposes
+	mockUser   string
+	mockRoles  []string
 }
 
 // NewMiddleware creates a new auth middleware
@@ -88,10 +90,22 @@ func NewMiddleware(config CognitoConfig) *Middleware {
 
 // NewMockMiddleware creates a middleware that skips authentication (for testing)
 func NewMockMiddleware() *Middleware {
+	return NewMockMiddlewareAs("test-user", RoleAdmin)
+}
+
+// NewMockMiddlewareAs creates a middleware that skips authentication and
+// authenticates every request as username with the given roles (for testing)
+func NewMockMiddlewareAs(username string, roles ...Role) *Middleware {
+	groups := make([]string, len(roles))
+	for i, role := range roles {
+		groups[i] = string(role)
+	}
 	return &Middleware{
 		config:    CognitoConfig{},
 		keysCache: make(map[string]*rsa.PublicKey),
 		skipAuth:  true,
+		mockUser:  username,
+		mockRoles: groups,
 	}
 }
 
@@ -102,8 +116,8 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 		if m.skipAuth {
 			// Add mock user info to context for testing
 			ctx := r.Context()
-			ctx = context.WithValue(ctx, userContextKey, "test-user")
-			ctx = context.WithValue(ctx, rolesContextKey, []string{string(RoleAdmin)})
+			ctx = context.WithValue(ctx, userContextKey, m.mockUser)
+			ctx = context.WithValue(ctx, rolesContextKey, m.mockRoles)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
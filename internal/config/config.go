@@ -4,6 +4,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type DBConfig struct {
@@ -30,10 +33,58 @@ type CognitoConfig struct {
 	JWKSURI    string
 }
 
+// TimerConfig controla o encerramento automático de cronômetros.
+type TimerConfig struct {
+	MaxRunning    time.Duration
+	CheckInterval time.Duration
+}
+
+// HourPolicyConfig define a política padrão de horas (0 desativa a regra).
+type HourPolicyConfig struct {
+	MaxDailyHours       float64
+	MaxWeeklyHours      float64
+	DailyOvertimeHours  float64
+	WeeklyOvertimeHours float64
+	WeekendRule         string
+}
+
+// EstimateConfig controla o aviso de lançamentos acima da estimativa da
+// tarefa: WarningFactor 1.2 avisa a partir de 20% acima (0 desativa).
+type EstimateConfig struct {
+	WarningFactor float64
+}
+
+// ProjectLifecycleConfig liga as regras verificadas ao concluir um projeto.
+type ProjectLifecycleConfig struct {
+	CompleteRequiresTasksDone       bool
+	CompleteRequiresApprovedEntries bool
+}
+
+// StorageConfig escolhe onde os anexos são guardados: "local" (diretório
+// LocalDir) ou "s3" (AWS ou endpoint compatível, como o LocalStack).
+type StorageConfig struct {
+	Backend          string
+	LocalDir         string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3SessionToken   string
+	S3PathStyle      bool
+	MaxUploadBytes   int64
+	AllowedMIMETypes []string
+}
+
 type AppConfig struct {
-	Env     string
-	DB      DBConfig
-	Cognito CognitoConfig
+	Env              string
+	DB               DBConfig
+	Cognito          CognitoConfig
+	Timer            TimerConfig
+	HourPolicy       HourPolicyConfig
+	Estimates        EstimateConfig
+	ProjectLifecycle ProjectLifecycleConfig
+	Storage          StorageConfig
 }
 
 func Load() AppConfig {
@@ -54,12 +105,97 @@ func Load() AppConfig {
 			JWTAudience: getenv("JWT_AUDIENCE", ""),
 			JWKSURI:     getenv("JWKS_URI", ""),
 		},
+		Timer: TimerConfig{
+			MaxRunning:    getduration("TIMER_MAX_RUNNING", 12*time.Hour),
+			CheckInterval: getduration("TIMER_CHECK_INTERVAL", time.Minute),
+		},
+		HourPolicy: HourPolicyConfig{
+			MaxDailyHours:       getfloat("HOURS_MAX_DAILY", 0),
+			MaxWeeklyHours:      getfloat("HOURS_MAX_WEEKLY", 0),
+			DailyOvertimeHours:  getfloat("HOURS_DAILY_OVERTIME", 0),
+			WeeklyOvertimeHours: getfloat("HOURS_WEEKLY_OVERTIME", 0),
+			WeekendRule:         getenv("HOURS_WEEKEND_RULE", "allow"),
+		},
+		Estimates: EstimateConfig{
+			WarningFactor: getfloat("ESTIMATE_WARNING_FACTOR", 0),
+		},
+		ProjectLifecycle: ProjectLifecycleConfig{
+			CompleteRequiresTasksDone:       getbool("PROJECT_COMPLETE_REQUIRES_TASKS_DONE", true),
+			CompleteRequiresApprovedEntries: getbool("PROJECT_COMPLETE_REQUIRES_APPROVED_ENTRIES", true),
+		},
+		Storage: StorageConfig{
+			Backend:          getenv("STORAGE_BACKEND", "local"),
+			LocalDir:         getenv("STORAGE_LOCAL_DIR", "./data/attachments"),
+			S3Endpoint:       getenv("S3_ENDPOINT", ""),
+			S3Region:         getenv("AWS_REGION", "us-east-1"),
+			S3Bucket:         getenv("S3_BUCKET", "grupo-l-terraform"),
+			S3AccessKey:      getenv("AWS_ACCESS_KEY_ID", ""),
+			S3SecretKey:      getenv("AWS_SECRET_ACCESS_KEY", ""),
+			S3SessionToken:   getenv("AWS_SESSION_TOKEN", ""),
+			S3PathStyle:      getbool("S3_FORCE_PATH_STYLE", false),
+			MaxUploadBytes:   int64(getfloat("ATTACHMENT_MAX_BYTES", 10<<20)),
+			AllowedMIMETypes: getlist("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentTypes),
+		},
 	}
 }
 
+// defaultAttachmentTypes cobre imagens, PDF, texto e documentos de escritório.
+var defaultAttachmentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"application/pdf",
+	"text/plain",
+	"text/csv",
+	"application/zip",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
 func getenv(k, def string) string {
 	if v := os.Getenv(k); v != "" {
 		return v
 	}
 	return def
 }
+
+func getduration(k string, def time.Duration) time.Duration {
+	if v := os.Getenv(k); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
+func getbool(k string, def bool) bool {
+	if v := os.Getenv(k); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+func getfloat(k string, def float64) float64 {
+	if v := os.Getenv(k); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+func getlist(k string, def []string) []string {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -21,6 +21,7 @@ func Open(cfg config.AppConfig) (*gorm.DB, error) {
 			SingularTable: false, // tabelas no plural
 		},
 		DisableForeignKeyConstraintWhenMigrating: true,
+		TranslateError: true, // violações de unicidade viram gorm.ErrDuplicatedKey
 		Logger:      logger.Default.LogMode(lvl),
 		PrepareStmt: true,
 	}
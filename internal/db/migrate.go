@@ -15,5 +15,28 @@ func AutoMigrate(db *gorm.DB) error {
 		&workspace.Project{},
 		&workspace.Task{},
 		&workspace.TimeEntry{},
+		&workspace.Timesheet{},
+		&workspace.Timer{},
+		&workspace.HourPolicy{},
+		&workspace.BillingRate{},
+		&workspace.Invoice{},
+		&workspace.InvoiceLine{},
+		&workspace.PeriodLock{},
+		&workspace.ApprovalDelegation{},
+		&workspace.ProjectMember{},
+		&workspace.ProjectStatusChange{},
+		&workspace.Client{},
+		&workspace.ClientContact{},
+		&workspace.Milestone{},
+		&workspace.ProjectTemplate{},
+		&workspace.ProjectTemplateMilestone{},
+		&workspace.ProjectTemplateTask{},
+		&workspace.Tag{},
+		&workspace.ProjectTag{},
+		&workspace.TaskTag{},
+		&workspace.TaskDependency{},
+		&workspace.TaskComment{},
+		&workspace.TaskCommentRevision{},
+		&workspace.TaskAttachment{},
 	)
 }
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const csvTimeLayout = "2006-01-02 15:04:05"
+
+type csvWriter struct {
+	w      *csv.Writer
+	locale Locale
+	rows   int
+}
+
+// NewCSVWriter writes rows as CSV using the locale's delimiter and decimal
+// separator. Output is flushed periodically so large exports stream.
+func NewCSVWriter(w io.Writer, locale Locale) Writer {
+	cw := csv.NewWriter(w)
+	cw.Comma = locale.Delimiter
+	return &csvWriter{w: cw, locale: locale}
+}
+
+func (c *csvWriter) WriteHeader(headers []string) error {
+	return c.w.Write(headers)
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = c.format(v)
+	}
+	if err := c.w.Write(record); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%500 == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) format(v any) string {
+	v = deref(v)
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		s := strconv.FormatFloat(val, 'f', -1, 64)
+		if c.locale.Decimal != '.' {
+			s = strings.Replace(s, ".", string(c.locale.Decimal), 1)
+		}
+		return s
+	case time.Time:
+		return val.UTC().Format(csvTimeLayout)
+	case fmt.Stringer:
+		return escapeFormula(val.String())
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.String:
+		return escapeFormula(rv.String())
+	}
+	return escapeFormula(fmt.Sprint(v))
+}
+
+// escapeFormula prefixes text that spreadsheets would evaluate as a formula
+// with a quote, so user input is shown as typed. Numbers are formatted above
+// and never reach it.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// deref unwraps typed pointers, returning nil for nil pointers.
+func deref(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
@@ -0,0 +1,136 @@
+// Package export writes tabular data as CSV or XLSX without loading the whole
+// result set in memory.
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format identifies an export file type.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV + "; charset=utf-8"
+}
+
+// ParseFormat accepts "csv" and "xlsx" (case insensitive).
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", value)
+	}
+}
+
+// FormatFromAccept picks an export format from an Accept header, returning
+// false when the client did not ask for a spreadsheet.
+func FormatFromAccept(accept string) (Format, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		switch strings.ToLower(mediaType) {
+		case ContentTypeCSV:
+			return FormatCSV, true
+		case ContentTypeXLSX:
+			return FormatXLSX, true
+		}
+	}
+	return "", false
+}
+
+// Locale controls how numbers are rendered in CSV output.
+type Locale struct {
+	Decimal   rune
+	Delimiter rune
+}
+
+// commaDecimalLanguages use a comma as decimal separator, so CSV files for
+// them are delimited by semicolons as spreadsheets expect.
+var commaDecimalLanguages = map[string]bool{
+	"pt": true, "es": true, "fr": true, "de": true, "it": true,
+	"nl": true, "ru": true, "pl": true, "tr": true, "sv": true,
+	"da": true, "fi": true, "nb": true, "cs": true,
+}
+
+// ParseLocale maps a language tag (e.g. "pt-BR") or Accept-Language header
+// to a Locale. Unknown or empty values fall back to dot decimals.
+func ParseLocale(tag string) Locale {
+	first := strings.TrimSpace(strings.SplitN(tag, ",", 2)[0])
+	first = strings.SplitN(first, ";", 2)[0]
+	lang := strings.ToLower(strings.SplitN(strings.ReplaceAll(first, "_", "-"), "-", 2)[0])
+	if commaDecimalLanguages[lang] {
+		return Locale{Decimal: ',', Delimiter: ';'}
+	}
+	return Locale{Decimal: '.', Delimiter: ','}
+}
+
+// Writer receives a header row followed by data rows. Cell values may be
+// string, bool, integers, float64, time.Time, pointers to those, or nil.
+type Writer interface {
+	WriteHeader(headers []string) error
+	WriteRow(values []any) error
+	Close() error
+}
+
+// Column describes one exportable field of a record type.
+type Column[T any] struct {
+	Key    string
+	Header string
+	Value  func(T) any
+}
+
+// SelectColumns returns the columns named in keys (in that order), or the
+// defaults when keys is empty.
+func SelectColumns[T any](all []Column[T], defaults, keys []string) ([]Column[T], error) {
+	if len(keys) == 0 {
+		keys = defaults
+	}
+	byKey := make(map[string]Column[T], len(all))
+	for _, c := range all {
+		byKey[c.Key] = c
+	}
+	selected := make([]Column[T], 0, len(keys))
+	for _, key := range keys {
+		c, ok := byKey[strings.TrimSpace(key)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", key)
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// Headers returns the header labels of the columns.
+func Headers[T any](columns []Column[T]) []string {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	return headers
+}
+
+// Row extracts the values of one record.
+func Row[T any](columns []Column[T], item T) []any {
+	values := make([]any, len(columns))
+	for i, c := range columns {
+		values[i] = c.Value(item)
+	}
+	return values
+}
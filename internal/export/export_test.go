@@ -0,0 +1,152 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type row struct {
+	ID    uint
+	Name  string
+	Hours float64
+	Date  time.Time
+	Done  *time.Time
+}
+
+var testColumns = []Column[row]{
+	{Key: "id", Header: "ID", Value: func(r row) any { return r.ID }},
+	{Key: "name", Header: "Name", Value: func(r row) any { return r.Name }},
+	{Key: "hours", Header: "Hours", Value: func(r row) any { return r.Hours }},
+	{Key: "date", Header: "Date", Value: func(r row) any { return r.Date }},
+	{Key: "done", Header: "Done", Value: func(r row) any { return r.Done }},
+}
+
+func TestCSVWriter_Locale(t *testing.T) {
+	columns, err := SelectColumns(testColumns, []string{"id", "name"}, []string{"name", "hours", "done"})
+	if err != nil {
+		t.Fatalf("select columns: %v", err)
+	}
+	item := row{ID: 1, Name: "Análise; revisão", Hours: 2.5}
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, ParseLocale("pt-BR,pt;q=0.9"))
+	if err := w.WriteHeader(Headers(columns)); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if err := w.WriteRow(Row(columns, item)); err != nil {
+		t.Fatalf("row: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	want := "Name;Hours;Done\n\"Análise; revisão\";2,5;\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected csv:\n%q\nwant\n%q", buf.String(), want)
+	}
+
+	if loc := ParseLocale("en-US"); loc.Decimal != '.' || loc.Delimiter != ',' {
+		t.Fatalf("unexpected english locale: %+v", loc)
+	}
+	if _, err := SelectColumns(testColumns, nil, []string{"missing"}); err == nil {
+		t.Fatal("expected error for unknown column")
+	}
+}
+
+func TestCSVWriter_EscapesFormulas(t *testing.T) {
+	columns, err := SelectColumns(testColumns, nil, []string{"id", "name", "hours"})
+	if err != nil {
+		t.Fatalf("select columns: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, ParseLocale("en-US"))
+	for _, item := range []row{
+		{ID: 1, Name: "=HYPERLINK(\"http://x\")", Hours: -1.5},
+		{ID: 2, Name: "+55 51 9999"},
+		{ID: 3, Name: "-revisão"},
+		{ID: 4, Name: "@SUM(A1)"},
+		{ID: 5, Name: "\tcmd"},
+		{ID: 6, Name: "Login = ok"},
+	} {
+		if err := w.WriteRow(Row(columns, item)); err != nil {
+			t.Fatalf("row: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	want := "1,\"'=HYPERLINK(\"\"http://x\"\")\",-1.5\n" +
+		"2,'+55 51 9999,0\n" +
+		"3,'-revisão,0\n" +
+		"4,'@SUM(A1),0\n" +
+		"5,'\tcmd,0\n" +
+		"6,Login = ok,0\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected csv:\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestXLSXWriter_TypedCells(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXWriter(&buf, "entries")
+	if err != nil {
+		t.Fatalf("new writer: %v", err)
+	}
+	if err := w.WriteHeader(Headers(testColumns)); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	date := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := w.WriteRow(Row(testColumns, row{ID: 7, Name: "A & B", Hours: 1.25, Date: date})); err != nil {
+		t.Fatalf("row: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open sheet: %v", err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet = string(data)
+	}
+	for _, want := range []string{
+		`<c r="A2"><v>7</v></c>`,
+		`<t xml:space="preserve">A &amp; B</t>`,
+		`<c r="C2"><v>1.25</v></c>`,
+		`<c r="D2" s="1"><v>45658.5</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Fatalf("sheet missing %s:\n%s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="E2"`) {
+		t.Fatal("nil values must produce empty cells")
+	}
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	if f, ok := FormatFromAccept("text/csv;q=0.9, application/json"); !ok || f != FormatCSV {
+		t.Fatalf("expected csv, got %q %v", f, ok)
+	}
+	if _, ok := FormatFromAccept("application/json"); ok {
+		t.Fatal("json must not trigger export")
+	}
+	if columnName(27) != "AB" {
+		t.Fatalf("unexpected column name %s", columnName(27))
+	}
+}
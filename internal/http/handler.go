@@ -11,6 +11,8 @@ import (
 
 	"github.com/v-Kaefer/Const-Software-25-02/internal/auth"
 	"github.com/v-Kaefer/Const-Software-25-02/internal/config"
+	"github.com/v-Kaefer/Const-Software-25-02/internal/export"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/timeimport"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
 	"gorm.io/gorm"
@@ -29,6 +31,16 @@ type Router struct {
 	projectSvc     *workspace.ProjectService
 	taskSvc        *workspace.TaskService
 	timeSvc        *workspace.TimeEntryService
+	timesheetSvc   *workspace.TimesheetService
+	timerSvc       *workspace.TimerService
+	billingSvc     *workspace.BillingService
+	reportSvc      *workspace.ReportService
+	importSvc      *timeimport.Service
+	periodSvc      *workspace.PeriodLockService
+	delegationSvc  *workspace.DelegationService
+	clientSvc      *workspace.ClientService
+	tagSvc         *workspace.TagService
+	attachmentSvc  *workspace.AttachmentService
 	authMiddleware *auth.Middleware
 	mux            *http.ServeMux
 }
@@ -38,6 +50,16 @@ func NewRouter(
 	projectSvc *workspace.ProjectService,
 	taskSvc *workspace.TaskService,
 	timeSvc *workspace.TimeEntryService,
+	timesheetSvc *workspace.TimesheetService,
+	timerSvc *workspace.TimerService,
+	billingSvc *workspace.BillingService,
+	reportSvc *workspace.ReportService,
+	importSvc *timeimport.Service,
+	periodSvc *workspace.PeriodLockService,
+	delegationSvc *workspace.DelegationService,
+	clientSvc *workspace.ClientService,
+	tagSvc *workspace.TagService,
+	attachmentSvc *workspace.AttachmentService,
 	authMiddleware *auth.Middleware,
 ) *Router {
 	r := &Router{
@@ -45,6 +67,16 @@ func NewRouter(
 		projectSvc:     projectSvc,
 		taskSvc:        taskSvc,
 		timeSvc:        timeSvc,
+		timesheetSvc:   timesheetSvc,
+		timerSvc:       timerSvc,
+		billingSvc:     billingSvc,
+		reportSvc:      reportSvc,
+		importSvc:      importSvc,
+		periodSvc:      periodSvc,
+		delegationSvc:  delegationSvc,
+		clientSvc:      clientSvc,
+		tagSvc:         tagSvc,
+		attachmentSvc:  attachmentSvc,
 		authMiddleware: authMiddleware,
 		mux:            http.NewServeMux(),
 	}
@@ -72,13 +104,22 @@ func (r *Router) routes() {
 	r.mux.Handle("DELETE "+apiPrefix+"/users/{id}", r.authMiddleware.Authenticate(
 		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteUser)),
 	))
+	r.mux.Handle("GET "+apiPrefix+"/users/{id}/hour-policy", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleGetHourPolicy),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/users/{id}/hour-policy", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleSetHourPolicy)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/users/{id}/hour-policy", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleResetHourPolicy)),
+	))
 
 	// Projetos
 	r.mux.Handle("POST "+apiPrefix+"/projects", r.authMiddleware.Authenticate(
 		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleCreateProject)),
 	))
 	r.mux.Handle("GET "+apiPrefix+"/projects", r.authMiddleware.Authenticate(
-		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleListProjects)),
+		http.HandlerFunc(r.handleListProjects),
 	))
 	r.mux.Handle("GET "+apiPrefix+"/projects/{id}", r.authMiddleware.Authenticate(
 		http.HandlerFunc(r.handleGetProject),
@@ -87,7 +128,126 @@ func (r *Router) routes() {
 		http.HandlerFunc(r.handleUpdateProject),
 	))
 	r.mux.Handle("DELETE "+apiPrefix+"/projects/{id}", r.authMiddleware.Authenticate(
-		http.HandlerFunc(r.handleDeleteProject),
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteProject)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/projects/{id}/status", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTransitionProject),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/status-history", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleProjectStatusHistory),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/projects/{id}/archive", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleArchiveProject),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/projects/{id}/unarchive", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUnarchiveProject),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/members", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListProjectMembers),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/projects/{id}/members/{userID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleSetProjectMember),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/projects/{id}/members/{userID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleRemoveProjectMember),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/projects/{id}/budget", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleSetProjectBudget),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/burn", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleGetProjectBurn),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/summary", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleProjectSummary),
+	))
+
+	// Clientes
+	r.mux.Handle("POST "+apiPrefix+"/clients", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleCreateClient)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/clients", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleListClients)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/clients/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleGetClient)),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/clients/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleUpdateClient)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/clients/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteClient)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/clients/{id}/summary", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleClientSummary)),
+	))
+
+	// Modelos e cópia de projetos
+	r.mux.Handle("POST "+apiPrefix+"/projects/{id}/templates", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleSaveProjectTemplate),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/projects/{id}/clone", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleCloneProject)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/project-templates", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleListProjectTemplates)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/project-templates/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleGetProjectTemplate)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/project-templates/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteProjectTemplate)),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/project-templates/{id}/projects", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleCreateProjectFromTemplate)),
+	))
+
+	// Marcos
+	r.mux.Handle("POST "+apiPrefix+"/projects/{id}/milestones", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleCreateMilestone),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/milestones", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListMilestones),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/milestones/{id}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleGetMilestone),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/milestones/{id}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUpdateMilestone),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/milestones/{id}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleDeleteMilestone),
+	))
+
+	// Tags
+	r.mux.Handle("POST "+apiPrefix+"/tags", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin, auth.RoleReviewer)(http.HandlerFunc(r.handleCreateTag)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tags", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTags),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/tags/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleUpdateTag)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/tags/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteTag)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/projects/{id}/tags", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListProjectTags),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/projects/{id}/tags/{tagID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTagProject),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/projects/{id}/tags/{tagID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUntagProject),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/tags", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTaskTags),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/tasks/{id}/tags/{tagID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTagTask),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/tasks/{id}/tags/{tagID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUntagTask),
 	))
 
 	// Tarefas
@@ -106,6 +266,54 @@ func (r *Router) routes() {
 	r.mux.Handle("PUT "+apiPrefix+"/tasks/{id}", r.authMiddleware.Authenticate(
 		http.HandlerFunc(r.handleUpdateTask),
 	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/children", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTaskChildren),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/tasks/{id}/parent", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleMoveTask),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/rollup", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTaskRollup),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/estimate", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTaskEstimate),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/dependencies", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTaskDependencies),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/tasks/{id}/dependencies/{predecessorID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleAddTaskDependency),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/tasks/{id}/dependencies/{predecessorID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleRemoveTaskDependency),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/tasks/{id}/comments", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleCreateTaskComment),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/comments", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTaskComments),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/tasks/{id}/comments/{commentID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUpdateTaskComment),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/tasks/{id}/comments/{commentID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleDeleteTaskComment),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/comments/{commentID}/history", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleTaskCommentHistory),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/tasks/{id}/attachments", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleUploadAttachment),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/attachments", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListAttachments),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/tasks/{id}/attachments/{attachmentID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleDownloadAttachment),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/tasks/{id}/attachments/{attachmentID}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleDeleteAttachment),
+	))
 
 	// Lançamentos de horas
 	r.mux.Handle("POST "+apiPrefix+"/tasks/{taskID}/time-entries", r.authMiddleware.Authenticate(
@@ -123,8 +331,136 @@ func (r *Router) routes() {
 	r.mux.Handle("PUT "+apiPrefix+"/time-entries/{id}", r.authMiddleware.Authenticate(
 		http.HandlerFunc(r.handleUpdateTimeEntry),
 	))
+	// aprovação: admin, dono do projeto (reviewers-group) ou substituto delegado
 	r.mux.Handle("PATCH "+apiPrefix+"/time-entries/{id}/approve", r.authMiddleware.Authenticate(
-		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleApproveTimeEntry)),
+		http.HandlerFunc(r.handleApproveTimeEntry),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/time-entries/{id}/reject", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleRejectTimeEntry),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/time-entries/{id}/resubmit", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleResubmitTimeEntry),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/time-entries/{id}/reopen", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleReopenTimeEntry)),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/time-entries/bulk-review", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleBulkReviewTimeEntries),
+	))
+
+	// Folhas de horas semanais
+	r.mux.Handle("GET "+apiPrefix+"/timesheets", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleListTimesheets),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/users/{id}/timesheets/{week}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleGetTimesheetWeek),
+	))
+	r.mux.Handle("PUT "+apiPrefix+"/users/{id}/timesheets/{week}", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleSaveTimesheetWeek),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/users/{id}/timesheets/{week}/submit", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleSubmitTimesheet),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/timesheets/{id}/approve", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleApproveTimesheet)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/timesheets/{id}/reject", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleRejectTimesheet)),
+	))
+
+	// Cronômetros
+	r.mux.Handle("GET "+apiPrefix+"/me/timer", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleGetTimer),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/me/timer", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleStartTimer),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/me/timer/pause", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handlePauseTimer),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/me/timer/resume", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleResumeTimer),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/me/timer/stop", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleStopTimer),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/me/timer", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleDiscardTimer),
+	))
+
+	// Faturamento (apenas admin)
+	r.mux.Handle("POST "+apiPrefix+"/billing-rates", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleCreateBillingRate)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/billing-rates", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleListBillingRates)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/billing-rates/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteBillingRate)),
+	))
+	r.mux.Handle("POST "+apiPrefix+"/projects/{id}/invoices", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleCreateInvoice)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/invoices", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleListInvoices)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/invoices/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleGetInvoice)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/invoices/{id}/issue", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleIssueInvoice)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/invoices/{id}/pay", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handlePayInvoice)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/invoices/{id}/void", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleVoidInvoice)),
+	))
+
+	// Relatórios
+	r.mux.Handle("GET "+apiPrefix+"/reports/hours", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleHoursReport),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/reports/estimate-variance", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleEstimateVarianceReport),
+	))
+
+	// Exportação CSV/XLSX (mesmos filtros e escopo das listagens)
+	r.mux.Handle("GET "+apiPrefix+"/exports/time-entries", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleExportTimeEntries),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/exports/tasks", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleExportTasks),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/exports/projects", r.authMiddleware.Authenticate(
+		http.HandlerFunc(r.handleExportProjects),
+	))
+
+	// Importação de lançamentos (Toggl, Harvest, Clockify; apenas admin)
+	r.mux.Handle("POST "+apiPrefix+"/imports/time-entries", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleImportTimeEntries)),
+	))
+
+	// Fechamento contábil de períodos (apenas admin)
+	r.mux.Handle("POST "+apiPrefix+"/period-locks", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleClosePeriod)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/period-locks", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleListPeriodLocks)),
+	))
+	r.mux.Handle("PATCH "+apiPrefix+"/period-locks/{id}/reopen", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleReopenPeriod)),
+	))
+
+	// Delegação de aprovação durante ausências (apenas admin)
+	r.mux.Handle("POST "+apiPrefix+"/approval-delegations", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleCreateDelegation)),
+	))
+	r.mux.Handle("GET "+apiPrefix+"/approval-delegations", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleListDelegations)),
+	))
+	r.mux.Handle("DELETE "+apiPrefix+"/approval-delegations/{id}", r.authMiddleware.Authenticate(
+		r.authMiddleware.RequireRole(auth.RoleAdmin)(http.HandlerFunc(r.handleDeleteDelegation)),
 	))
 }
 
@@ -150,6 +486,12 @@ func NewMockAuthMiddleware() *auth.Middleware {
 	return auth.NewMockMiddleware()
 }
 
+// NewMockAuthMiddlewareAs creates a mock auth middleware for testing that
+// authenticates every request as username with the given roles
+func NewMockAuthMiddlewareAs(username string, roles ...auth.Role) *auth.Middleware {
+	return auth.NewMockMiddlewareAs(username, roles...)
+}
+
 // === Handlers: Usuários ===
 
 func (r *Router) handleCreateUser(w http.ResponseWriter, req *http.Request) {
@@ -318,6 +660,7 @@ func (r *Router) handleDeleteUser(w http.ResponseWriter, req *http.Request) {
 func (r *Router) handleCreateProject(w http.ResponseWriter, req *http.Request) {
 	type in struct {
 		Name        string  `json:"name"`
+		ClientID    *uint   `json:"clientId"`
 		ClientName  string  `json:"clientName"`
 		Description string  `json:"description"`
 		StartDate   string  `json:"startDate"`
@@ -349,6 +692,7 @@ func (r *Router) handleCreateProject(w http.ResponseWriter, req *http.Request) {
 
 	project, err := r.projectSvc.CreateProject(ctx, workspace.ProjectInput{
 		Name:        body.Name,
+		ClientID:    body.ClientID,
 		ClientName:  body.ClientName,
 		Description: body.Description,
 		StartDate:   start,
@@ -356,7 +700,7 @@ func (r *Router) handleCreateProject(w http.ResponseWriter, req *http.Request) {
 		OwnerID:     current.ID,
 	})
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondProjectError(w, err)
 		return
 	}
 	respondJSON(w, http.StatusCreated, project)
@@ -364,27 +708,21 @@ func (r *Router) handleCreateProject(w http.ResponseWriter, req *http.Request) {
 
 func (r *Router) handleListProjects(w http.ResponseWriter, req *http.Request) {
 	page, pageSize := paginationParams(req)
-	statuses := projectStatusesFromQuery(req.URL.Query()["status"])
-	client := req.URL.Query().Get("client")
 
 	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
 	defer cancel()
 
-	filter := workspace.ProjectFilter{
-		Page:     page,
-		PageSize: pageSize,
-		Status:   statuses,
-		Client:   client,
+	filter, err := r.projectFilterFromRequest(ctx, req)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
 	}
-
-	if !r.hasAnyRole(ctx, auth.RoleAdmin) {
-		current, err := r.currentUser(ctx)
-		if err != nil {
-			respondError(w, http.StatusForbidden, "user not registered in system")
-			return
-		}
-		filter.OwnerID = &current.ID
+	if format, ok := export.FormatFromAccept(req.Header.Get("Accept")); ok {
+		r.exportProjects(w, req, filter, format)
+		return
 	}
+	filter.Page = page
+	filter.PageSize = pageSize
 
 	result, err := r.projectSvc.ListProjects(ctx, filter)
 	if err != nil {
@@ -395,6 +733,30 @@ func (r *Router) handleListProjects(w http.ResponseWriter, req *http.Request) {
 	respondPaginated(w, result.Items, page, pageSize, result.Total)
 }
 
+func (r *Router) projectFilterFromRequest(ctx context.Context, req *http.Request) (workspace.ProjectFilter, error) {
+	filter := workspace.ProjectFilter{
+		Status: projectStatusesFromQuery(req.URL.Query()["status"]),
+		Client: req.URL.Query().Get("client"),
+		Tags:   tagsFromQuery(req.URL.Query()["tag"]),
+	}
+	if includeArchived, err := strconv.ParseBool(req.URL.Query().Get("includeArchived")); err == nil {
+		filter.IncludeArchived = includeArchived
+	}
+	if clientID, err := strconv.ParseUint(req.URL.Query().Get("clientId"), 10, 32); err == nil {
+		id := uint(clientID)
+		filter.ClientID = &id
+	}
+
+	if !r.hasAnyRole(ctx, auth.RoleAdmin) {
+		current, err := r.currentUser(ctx)
+		if err != nil {
+			return filter, err
+		}
+		filter.VisibleTo = &current.ID
+	}
+	return filter, nil
+}
+
 func (r *Router) handleGetProject(w http.ResponseWriter, req *http.Request) {
 	projectID, err := parseUintParam(req, "id")
 	if err != nil {
@@ -415,7 +777,7 @@ func (r *Router) handleGetProject(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if !r.canManageProject(ctx, project) {
+	if !r.canViewProject(ctx, project) {
 		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
@@ -431,6 +793,7 @@ func (r *Router) handleUpdateProject(w http.ResponseWriter, req *http.Request) {
 	}
 	type in struct {
 		Name        string  `json:"name"`
+		ClientID    *uint   `json:"clientId"`
 		ClientName  string  `json:"clientName"`
 		Description string  `json:"description"`
 		Status      string  `json:"status"`
@@ -472,21 +835,24 @@ func (r *Router) handleUpdateProject(w http.ResponseWriter, req *http.Request) {
 		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
 
 	updated, err := r.projectSvc.UpdateProject(ctx, projectID, workspace.ProjectUpdateInput{
 		Name:        body.Name,
+		ClientID:    body.ClientID,
 		ClientName:  body.ClientName,
 		Description: body.Description,
 		Status:      status,
 		StartDate:   start,
 		EndDate:     endTime,
+		UpdatedBy:   current.ID,
 	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			respondError(w, http.StatusNotFound, "project not found")
-		} else {
-			respondError(w, http.StatusBadRequest, err.Error())
-		}
+		respondProjectError(w, err)
 		return
 	}
 	respondJSON(w, http.StatusOK, updated)
@@ -502,31 +868,90 @@ func (r *Router) handleDeleteProject(w http.ResponseWriter, req *http.Request) {
 	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
 	defer cancel()
 
-	project, err := r.projectSvc.GetProject(ctx, projectID)
-	if err != nil {
+	if _, ok := r.loadProject(ctx, w, projectID); !ok {
+		return
+	}
+
+	if err := r.projectSvc.DeleteProject(ctx, projectID); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			respondError(w, http.StatusNotFound, "project not found")
+		} else if errors.Is(err, workspace.ErrProjectHasInvoices) || errors.Is(err, workspace.ErrProjectHasApprovedHours) ||
+			errors.Is(err, workspace.ErrProjectHasAttachments) {
+			respondError(w, http.StatusConflict, err.Error())
 		} else {
-			respondError(w, http.StatusInternalServerError, "failed to load project")
+			respondError(w, http.StatusInternalServerError, "failed to delete project")
 		}
 		return
 	}
 
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleArchiveProject(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
 	if !r.canManageProject(ctx, project) {
 		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
 
-	if err := r.projectSvc.DeleteProject(ctx, projectID); err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			respondError(w, http.StatusNotFound, "project not found")
-		} else {
-			respondError(w, http.StatusInternalServerError, "failed to delete project")
+	archived, err := r.projectSvc.ArchiveProject(ctx, projectID, current.ID)
+	if err != nil {
+		if errors.Is(err, workspace.ErrProjectHasActiveTimers) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
 		}
+		respondError(w, http.StatusInternalServerError, "failed to archive project")
 		return
 	}
+	respondJSON(w, http.StatusOK, archived)
+}
 
-	w.WriteHeader(http.StatusNoContent)
+func (r *Router) handleUnarchiveProject(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	unarchived, err := r.projectSvc.UnarchiveProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, workspace.ErrProjectNotArchived) {
+			respondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to unarchive project")
+		return
+	}
+	respondJSON(w, http.StatusOK, unarchived)
 }
 
 // === Handlers: Tarefas ===
@@ -538,10 +963,14 @@ func (r *Router) handleCreateTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	type in struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description"`
-		AssigneeID  uint    `json:"assigneeId"`
-		DueDate     *string `json:"dueDate"`
+		Title                  string   `json:"title"`
+		Description            string   `json:"description"`
+		AssigneeID             uint     `json:"assigneeId"`
+		DueDate                *string  `json:"dueDate"`
+		EstimateHours          float64  `json:"estimateHours"`
+		RemainingEstimateHours *float64 `json:"remainingEstimateHours"`
+		MilestoneID            *uint    `json:"milestoneId"`
+		ParentID               *uint    `json:"parentId"`
 	}
 	var body in
 	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
@@ -572,14 +1001,22 @@ func (r *Router) handleCreateTask(w http.ResponseWriter, req *http.Request) {
 	}
 
 	task, err := r.taskSvc.CreateTask(ctx, workspace.TaskInput{
-		ProjectID:   projectID,
-		Title:       body.Title,
-		Description: body.Description,
-		AssigneeID:  body.AssigneeID,
-		DueDate:     due,
+		ProjectID:              projectID,
+		Title:                  body.Title,
+		Description:            body.Description,
+		AssigneeID:             body.AssigneeID,
+		DueDate:                due,
+		EstimateHours:          body.EstimateHours,
+		RemainingEstimateHours: body.RemainingEstimateHours,
+		MilestoneID:            body.MilestoneID,
+		ParentID:               body.ParentID,
 	})
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		if errors.Is(err, workspace.ErrProjectArchived) {
+			respondError(w, http.StatusConflict, err.Error())
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
 		return
 	}
 	respondJSON(w, http.StatusCreated, task)
@@ -606,7 +1043,7 @@ func (r *Router) handleListProjectTasks(w http.ResponseWriter, req *http.Request
 		}
 		return
 	}
-	if !r.canManageProject(ctx, project) {
+	if !r.canViewProject(ctx, project) {
 		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
@@ -626,46 +1063,64 @@ func (r *Router) handleListProjectTasks(w http.ResponseWriter, req *http.Request
 
 func (r *Router) handleListTasks(w http.ResponseWriter, req *http.Request) {
 	page, pageSize := paginationParams(req)
-	statuses := taskStatusesFromQuery(req.URL.Query()["status"])
 
 	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
 	defer cancel()
 
+	filter, err := r.taskFilterFromRequest(ctx, req)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	if format, ok := export.FormatFromAccept(req.Header.Get("Accept")); ok {
+		r.exportTasks(w, req, filter, format)
+		return
+	}
+	filter.Page = page
+	filter.PageSize = pageSize
+
+	result, err := r.taskSvc.ListTasks(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list tasks")
+		return
+	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) taskFilterFromRequest(ctx context.Context, req *http.Request) (workspace.TaskFilter, error) {
 	filter := workspace.TaskFilter{
-		Status:   statuses,
-		Page:     page,
-		PageSize: pageSize,
+		Status: taskStatusesFromQuery(req.URL.Query()["status"]),
+		Tags:   tagsFromQuery(req.URL.Query()["tag"]),
 	}
 
-	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" && r.hasAnyRole(ctx, auth.RoleAdmin) {
+	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" {
 		if projID, err := strconv.ParseUint(projectIDStr, 10, 32); err == nil {
 			filter.ProjectID = uint(projID)
 		}
 	}
-
-	if r.hasAnyRole(ctx, auth.RoleAdmin) {
-		if assigneeStr := req.URL.Query().Get("assigneeId"); assigneeStr != "" {
-			if assigneeID, err := strconv.ParseUint(assigneeStr, 10, 32); err == nil {
-				id := uint(assigneeID)
-				filter.AssigneeID = &id
-			}
+	if assigneeStr := req.URL.Query().Get("assigneeId"); assigneeStr != "" {
+		if assigneeID, err := strconv.ParseUint(assigneeStr, 10, 32); err == nil {
+			id := uint(assigneeID)
+			filter.AssigneeID = &id
 		}
-	} else {
+	}
+	if milestoneStr := req.URL.Query().Get("milestoneId"); milestoneStr != "" {
+		if milestoneID, err := strconv.ParseUint(milestoneStr, 10, 32); err == nil {
+			id := uint(milestoneID)
+			filter.MilestoneID = &id
+		}
+	}
+
+	// demais usuários veem as tarefas atribuídas e as dos projetos de que participam
+	if !r.hasAnyRole(ctx, auth.RoleAdmin) {
 		current, err := r.currentUser(ctx)
 		if err != nil {
-			respondError(w, http.StatusForbidden, "user not registered in system")
-			return
+			return filter, err
 		}
 		id := current.ID
-		filter.AssigneeID = &id
+		filter.VisibleTo = &id
 	}
-
-	result, err := r.taskSvc.ListTasks(ctx, filter)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "unable to list tasks")
-		return
-	}
-	respondPaginated(w, result.Items, page, pageSize, result.Total)
+	return filter, nil
 }
 
 func (r *Router) handleGetTask(w http.ResponseWriter, req *http.Request) {
@@ -703,11 +1158,15 @@ func (r *Router) handleUpdateTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 	type in struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description"`
-		Status      string  `json:"status"`
-		AssigneeID  uint    `json:"assigneeId"`
-		DueDate     *string `json:"dueDate"`
+		Title                  string   `json:"title"`
+		Description            string   `json:"description"`
+		Status                 string   `json:"status"`
+		AssigneeID             uint     `json:"assigneeId"`
+		DueDate                *string  `json:"dueDate"`
+		EstimateHours          float64  `json:"estimateHours"`
+		RemainingEstimateHours *float64 `json:"remainingEstimateHours"`
+		MilestoneID            *uint    `json:"milestoneId"`
+		OverrideDependencies   bool     `json:"overrideDependencies"`
 	}
 	var body in
 	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
@@ -733,21 +1192,34 @@ func (r *Router) handleUpdateTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if !r.canManageProject(ctx, &task.Project) {
+	canManage := r.canManageProject(ctx, &task.Project)
+	// ignorar predecessoras abertas é decisão de quem gerencia o projeto
+	if body.OverrideDependencies && !canManage {
+		respondError(w, http.StatusForbidden, "only project managers can override dependencies")
+		return
+	}
+	if !canManage {
 		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
 
 	updated, err := r.taskSvc.UpdateTask(ctx, taskID, workspace.TaskUpdateInput{
-		Title:       body.Title,
-		Description: body.Description,
-		Status:      workspace.TaskStatus(strings.ToLower(body.Status)),
-		AssigneeID:  body.AssigneeID,
-		DueDate:     due,
+		Title:                  body.Title,
+		Description:            body.Description,
+		Status:                 workspace.TaskStatus(strings.ToLower(body.Status)),
+		AssigneeID:             body.AssigneeID,
+		DueDate:                due,
+		EstimateHours:          body.EstimateHours,
+		RemainingEstimateHours: body.RemainingEstimateHours,
+		MilestoneID:            body.MilestoneID,
+		OverrideDependencies:   body.OverrideDependencies,
 	})
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			respondError(w, http.StatusNotFound, "task not found")
+		} else if errors.Is(err, workspace.ErrProjectArchived) || errors.Is(err, workspace.ErrTaskHasOpenSubtasks) ||
+			errors.Is(err, workspace.ErrTaskBlockedByPredecessors) {
+			respondError(w, http.StatusConflict, err.Error())
 		} else {
 			respondError(w, http.StatusBadRequest, err.Error())
 		}
@@ -813,7 +1285,7 @@ func (r *Router) handleCreateTimeEntry(w http.ResponseWriter, req *http.Request)
 		Notes:     body.Notes,
 	})
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondTimeEntryError(w, err)
 		return
 	}
 
@@ -831,19 +1303,12 @@ func (r *Router) handleListTaskEntries(w http.ResponseWriter, req *http.Request)
 	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
 	defer cancel()
 
-	task, err := r.taskSvc.GetTask(ctx, taskID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			respondError(w, http.StatusNotFound, "task not found")
-		} else {
-			respondError(w, http.StatusInternalServerError, "failed to load task")
-		}
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
 		return
 	}
-
-	current, err := r.currentUser(ctx)
-	if err != nil {
-		respondError(w, http.StatusForbidden, "user not registered in system")
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
 		return
 	}
 
@@ -852,14 +1317,14 @@ func (r *Router) handleListTaskEntries(w http.ResponseWriter, req *http.Request)
 		Page:     page,
 		PageSize: pageSize,
 	}
-
-	if r.canManageProject(ctx, &task.Project) {
-		// nothing, managers can see all entries
-	} else if task.AssigneeID == current.ID {
+	// gestores do projeto veem todos os lançamentos; os demais, só os próprios
+	if !r.canManageProject(ctx, &task.Project) {
+		current, err := r.currentUser(ctx)
+		if err != nil {
+			respondError(w, http.StatusForbidden, "user not registered in system")
+			return
+		}
 		filter.UserID = &current.ID
-	} else {
-		respondError(w, http.StatusForbidden, "insufficient permissions")
-		return
 	}
 
 	result, err := r.timeSvc.ListEntries(ctx, filter)
@@ -875,16 +1340,41 @@ func (r *Router) handleListTimeEntries(w http.ResponseWriter, req *http.Request)
 	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
 	defer cancel()
 
-	filter := workspace.TimeEntryFilter{
-		Page:     page,
-		PageSize: pageSize,
+	filter, err := r.timeEntryFilterFromRequest(ctx, req)
+	if err != nil {
+		respondTimeEntryFilterError(w, err)
+		return
+	}
+	if format, ok := export.FormatFromAccept(req.Header.Get("Accept")); ok {
+		r.exportTimeEntries(w, req, filter, format)
+		return
+	}
+	filter.Page = page
+	filter.PageSize = pageSize
+
+	result, err := r.timeSvc.ListEntries(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list time entries")
+		return
 	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) timeEntryFilterFromRequest(ctx context.Context, req *http.Request) (workspace.TimeEntryFilter, error) {
+	var filter workspace.TimeEntryFilter
 
 	if approvedParam := req.URL.Query().Get("approved"); approvedParam != "" {
 		if approved, err := strconv.ParseBool(approvedParam); err == nil {
 			filter.Approved = &approved
 		}
 	}
+	if statusParam := req.URL.Query().Get("status"); statusParam != "" {
+		status, err := workspace.ParseTimeEntryStatus(statusParam)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = &status
+	}
 
 	if taskIDStr := req.URL.Query().Get("taskId"); taskIDStr != "" && r.hasAnyRole(ctx, auth.RoleAdmin, auth.RoleReviewer) {
 		if taskID, err := strconv.ParseUint(taskIDStr, 10, 32); err == nil {
@@ -892,6 +1382,22 @@ func (r *Router) handleListTimeEntries(w http.ResponseWriter, req *http.Request)
 			filter.TaskID = &id
 		}
 	}
+	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" && r.hasAnyRole(ctx, auth.RoleAdmin, auth.RoleReviewer) {
+		if projectID, err := strconv.ParseUint(projectIDStr, 10, 32); err == nil {
+			id := uint(projectID)
+			filter.ProjectID = &id
+		}
+	}
+	if from := req.URL.Query().Get("from"); from != "" {
+		if t, err := parseTimeISO(from); err == nil {
+			filter.From = &t
+		}
+	}
+	if to := req.URL.Query().Get("to"); to != "" {
+		if t, err := parseTimeISO(to); err == nil {
+			filter.Until = &t
+		}
+	}
 
 	if r.hasAnyRole(ctx, auth.RoleAdmin) {
 		if userIDStr := req.URL.Query().Get("userId"); userIDStr != "" {
@@ -903,19 +1409,31 @@ func (r *Router) handleListTimeEntries(w http.ResponseWriter, req *http.Request)
 	} else {
 		current, err := r.currentUser(ctx)
 		if err != nil {
-			respondError(w, http.StatusForbidden, "user not registered in system")
-			return
+			return filter, err
+		}
+		// reviewable=true lista a fila de quem aprova em vez dos próprios lançamentos
+		if reviewable, _ := strconv.ParseBool(req.URL.Query().Get("reviewable")); reviewable {
+			scope, err := r.reviewScope(ctx, current)
+			if err != nil {
+				return filter, err
+			}
+			filter = scope.Apply(filter)
+		} else {
+			id := current.ID
+			filter.UserID = &id
 		}
-		id := current.ID
-		filter.UserID = &id
 	}
+	return filter, nil
+}
 
-	result, err := r.timeSvc.ListEntries(ctx, filter)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "unable to list time entries")
+// respondTimeEntryFilterError answers 400 for an invalid status and 403 when
+// the caller is not registered.
+func respondTimeEntryFilterError(w http.ResponseWriter, err error) {
+	if errors.Is(err, workspace.ErrInvalidTimeEntryStatus) {
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	respondPaginated(w, result.Items, page, pageSize, result.Total)
+	respondError(w, http.StatusForbidden, "user not registered in system")
 }
 
 func (r *Router) handleGetTimeEntry(w http.ResponseWriter, req *http.Request) {
@@ -1000,7 +1518,7 @@ func (r *Router) handleUpdateTimeEntry(w http.ResponseWriter, req *http.Request)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			respondError(w, http.StatusNotFound, "entry not found")
 		} else {
-			respondError(w, http.StatusBadRequest, err.Error())
+			respondTimeEntryError(w, err)
 		}
 		return
 	}
@@ -1023,13 +1541,136 @@ func (r *Router) handleApproveTimeEntry(w http.ResponseWriter, req *http.Request
 		respondError(w, http.StatusForbidden, "user not registered in system")
 		return
 	}
+	if !r.authorizeReview(ctx, w, entryID, current) {
+		return
+	}
 
 	entry, err := r.timeSvc.ApproveEntry(ctx, entryID, current.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			respondError(w, http.StatusNotFound, "entry not found")
 		} else {
-			respondError(w, http.StatusBadRequest, err.Error())
+			respondTimeEntryError(w, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+func (r *Router) handleRejectTimeEntry(w http.ResponseWriter, req *http.Request) {
+	entryID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry id")
+		return
+	}
+	type in struct {
+		Reason string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	if !r.authorizeReview(ctx, w, entryID, current) {
+		return
+	}
+
+	entry, err := r.timeSvc.RejectEntry(ctx, entryID, current.ID, body.Reason)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "entry not found")
+		} else {
+			respondTimeEntryError(w, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+func (r *Router) handleResubmitTimeEntry(w http.ResponseWriter, req *http.Request) {
+	entryID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	entry, err := r.timeSvc.GetEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "entry not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load entry")
+		}
+		return
+	}
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	if entry.UserID != current.ID && !r.hasAnyRole(ctx, auth.RoleAdmin) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	resubmitted, err := r.timeSvc.ResubmitEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "entry not found")
+		} else {
+			respondTimeEntryError(w, err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resubmitted)
+}
+
+func (r *Router) handleReopenTimeEntry(w http.ResponseWriter, req *http.Request) {
+	entryID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid entry id")
+		return
+	}
+	type in struct {
+		Reason string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	entry, err := r.timeSvc.ReopenEntry(ctx, entryID, current.ID, body.Reason)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "entry not found")
+		} else {
+			respondTimeEntryError(w, err)
 		}
 		return
 	}
@@ -1049,6 +1690,20 @@ func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
 
+// respondTimeEntryError maps domain errors raised while writing time entries.
+func respondTimeEntryError(w http.ResponseWriter, err error) {
+	if errors.Is(err, workspace.ErrTimesheetLocked) || errors.Is(err, workspace.ErrEntryInvoiced) ||
+		errors.Is(err, workspace.ErrPeriodClosed) || errors.Is(err, workspace.ErrProjectArchived) {
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, workspace.ErrPolicyViolation) || errors.Is(err, workspace.ErrBudgetExhausted) {
+		respondError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	respondError(w, http.StatusBadRequest, err.Error())
+}
+
 func respondPaginated(w http.ResponseWriter, data interface{}, page, pageSize int, total int64) {
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"data": data,
@@ -1079,6 +1734,85 @@ func paginationParams(req *http.Request) (int, int) {
 	return page, pageSize
 }
 
+func (r *Router) handleBulkReviewTimeEntries(w http.ResponseWriter, req *http.Request) {
+	type filterIn struct {
+		UserID    *uint   `json:"userId"`
+		TaskID    *uint   `json:"taskId"`
+		ProjectID *uint   `json:"projectId"`
+		From      *string `json:"from"`
+		To        *string `json:"to"`
+		Status    *string `json:"status"`
+	}
+	type in struct {
+		Action string    `json:"action"`
+		IDs    []uint    `json:"ids"`
+		Filter *filterIn `json:"filter"`
+		Reason string    `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	input := workspace.BulkReviewInput{
+		Action: workspace.BulkReviewAction(strings.ToLower(body.Action)),
+		IDs:    body.IDs,
+		Reason: body.Reason,
+	}
+	if body.Filter != nil {
+		from, err := parseOptionalTimeISO(body.Filter.From)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid from date")
+			return
+		}
+		until, err := parseOptionalTimeISO(body.Filter.To)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid to date")
+			return
+		}
+		filter := workspace.TimeEntryFilter{
+			UserID:    body.Filter.UserID,
+			TaskID:    body.Filter.TaskID,
+			ProjectID: body.Filter.ProjectID,
+			From:      from,
+			Until:     until,
+		}
+		if body.Filter.Status != nil && *body.Filter.Status != "" {
+			status, err := workspace.ParseTimeEntryStatus(*body.Filter.Status)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			filter.Status = &status
+		}
+		input.Filter = &filter
+	}
+
+	// lotes grandes podem levar mais que o timeout padrão
+	ctx, cancel := context.WithTimeout(req.Context(), 60*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	input.ReviewerID = current.ID
+	input.Scope, err = r.reviewScope(ctx, current)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to resolve review scope")
+		return
+	}
+
+	result, err := r.timeSvc.BulkReview(ctx, input)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
 func parseUintParam(req *http.Request, key string) (uint, error) {
 	value := req.PathValue(key)
 	id, err := strconv.ParseUint(value, 10, 32)
@@ -1125,6 +1859,19 @@ func taskStatusesFromQuery(values []string) []workspace.TaskStatus {
 	return statuses
 }
 
+// tagsFromQuery reads repeated or comma-separated tag names.
+func tagsFromQuery(values []string) []string {
+	var tags []string
+	for _, raw := range values {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				tags = append(tags, part)
+			}
+		}
+	}
+	return tags
+}
+
 func (r *Router) currentUser(ctx context.Context) (*user.User, error) {
 	username, ok := auth.GetUserFromContext(ctx)
 	if !ok {
@@ -1164,6 +1911,19 @@ func (r *Router) isAdminOrOwner(ctx context.Context, userID uint) bool {
 	return current.ID == userID
 }
 
+// projectRole resolves the user's role on the project: owners count as
+// managers, otherwise the membership role (empty when not a member).
+func (r *Router) projectRole(ctx context.Context, project *workspace.Project, current *user.User) workspace.ProjectRole {
+	if project.OwnerID == current.ID {
+		return workspace.ProjectManager
+	}
+	role, err := r.projectSvc.MemberRole(ctx, project.ID, current.ID)
+	if err != nil {
+		return ""
+	}
+	return role
+}
+
 func (r *Router) canManageProject(ctx context.Context, project *workspace.Project) bool {
 	if r.isAdmin(ctx) {
 		return true
@@ -1172,7 +1932,18 @@ func (r *Router) canManageProject(ctx context.Context, project *workspace.Projec
 	if err != nil {
 		return false
 	}
-	return project.OwnerID == current.ID
+	return r.projectRole(ctx, project, current).AtLeast(workspace.ProjectManager)
+}
+
+func (r *Router) canViewProject(ctx context.Context, project *workspace.Project) bool {
+	if r.isAdmin(ctx) {
+		return true
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		return false
+	}
+	return r.projectRole(ctx, project, current).AtLeast(workspace.ProjectViewer)
 }
 
 func (r *Router) canAccessTask(ctx context.Context, task *workspace.Task) bool {
@@ -1186,7 +1957,7 @@ func (r *Router) canAccessTask(ctx context.Context, task *workspace.Task) bool {
 	if task.AssigneeID == current.ID {
 		return true
 	}
-	return task.Project.OwnerID == current.ID
+	return r.projectRole(ctx, &task.Project, current).AtLeast(workspace.ProjectViewer)
 }
 
 func (r *Router) canLogTimeOnTask(ctx context.Context, task *workspace.Task, current *user.User) bool {
@@ -1196,7 +1967,10 @@ func (r *Router) canLogTimeOnTask(ctx context.Context, task *workspace.Task, cur
 	if task.AssigneeID == current.ID {
 		return true
 	}
-	return task.Project.OwnerID == current.ID && r.hasAnyRole(ctx, auth.RoleReviewer)
+	if task.Project.OwnerID == current.ID {
+		return r.hasAnyRole(ctx, auth.RoleReviewer)
+	}
+	return r.projectRole(ctx, &task.Project, current).AtLeast(workspace.ProjectContributor)
 }
 
 func (r *Router) canViewEntry(ctx context.Context, entry *workspace.TimeEntry) bool {
@@ -1214,5 +1988,47 @@ func (r *Router) canViewEntry(ctx context.Context, entry *workspace.TimeEntry) b
 	if err != nil {
 		return false
 	}
-	return task.Project.OwnerID == current.ID
+	if r.projectRole(ctx, &task.Project, current).AtLeast(workspace.ProjectManager) {
+		return true
+	}
+	scope, err := r.reviewScope(ctx, current)
+	return err == nil && scope.Allows(task.ProjectID, entry.UserID)
+}
+
+// reviewScope resolves which entries a non-admin may approve or reject: the
+// projects they own when in reviewers-group, plus active delegations. Admins
+// get nil, meaning every entry.
+func (r *Router) reviewScope(ctx context.Context, current *user.User) (*workspace.ReviewScope, error) {
+	if r.isAdmin(ctx) {
+		return nil, nil
+	}
+	return r.delegationSvc.ReviewScope(ctx, current.ID, r.hasAnyRole(ctx, auth.RoleReviewer), time.Now().UTC())
+}
+
+// authorizeReview writes 404/403 and returns false when the current user may
+// not review the entry.
+func (r *Router) authorizeReview(ctx context.Context, w http.ResponseWriter, entryID uint, current *user.User) bool {
+	entry, err := r.timeSvc.GetEntry(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "entry not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load entry")
+		}
+		return false
+	}
+	scope, err := r.reviewScope(ctx, current)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to resolve review scope")
+		return false
+	}
+	if scope == nil {
+		return true
+	}
+	task, err := r.taskSvc.GetTask(ctx, entry.TaskID)
+	if err != nil || !scope.Allows(task.ProjectID, entry.UserID) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return false
+	}
+	return true
 }
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Delegação de aprovação ===
+
+func (r *Router) handleCreateDelegation(w http.ResponseWriter, req *http.Request) {
+	type in struct {
+		DelegatorID uint   `json:"delegatorId"`
+		DelegateID  uint   `json:"delegateId"`
+		ProjectID   *uint  `json:"projectId"`
+		StartsOn    string `json:"startsOn"`
+		EndsOn      string `json:"endsOn"`
+		Reason      string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	startsOn, err := parseTimeISO(body.StartsOn)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid startsOn")
+		return
+	}
+	endsOn, err := parseTimeISO(body.EndsOn)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid endsOn")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	for _, id := range []uint{body.DelegatorID, body.DelegateID} {
+		if _, err := r.userSvc.GetByID(ctx, id); err != nil {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+	}
+
+	delegation, err := r.delegationSvc.CreateDelegation(ctx, workspace.DelegationInput{
+		DelegatorID: body.DelegatorID,
+		DelegateID:  body.DelegateID,
+		ProjectID:   body.ProjectID,
+		StartsOn:    startsOn,
+		EndsOn:      endsOn,
+		Reason:      body.Reason,
+		CreatedBy:   current.ID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "project not found")
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, delegation)
+}
+
+func (r *Router) handleListDelegations(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	var filter workspace.DelegationFilter
+	if delegatorIDStr := req.URL.Query().Get("delegatorId"); delegatorIDStr != "" {
+		if delegatorID, err := strconv.ParseUint(delegatorIDStr, 10, 32); err == nil {
+			id := uint(delegatorID)
+			filter.DelegatorID = &id
+		}
+	}
+	if delegateIDStr := req.URL.Query().Get("delegateId"); delegateIDStr != "" {
+		if delegateID, err := strconv.ParseUint(delegateIDStr, 10, 32); err == nil {
+			id := uint(delegateID)
+			filter.DelegateID = &id
+		}
+	}
+	if activeOn := req.URL.Query().Get("activeOn"); activeOn != "" {
+		if t, err := parseTimeISO(activeOn); err == nil {
+			filter.ActiveOn = &t
+		}
+	}
+
+	delegations, err := r.delegationSvc.ListDelegations(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list delegations")
+		return
+	}
+	respondJSON(w, http.StatusOK, delegations)
+}
+
+func (r *Router) handleDeleteDelegation(w http.ResponseWriter, req *http.Request) {
+	delegationID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid delegation id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.delegationSvc.DeleteDelegation(ctx, delegationID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "delegation not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to delete delegation")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
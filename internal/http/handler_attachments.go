@@ -0,0 +1,231 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// attachmentTimeout covers the transfer to and from the blob store.
+const attachmentTimeout = time.Minute
+
+// === Handlers: Anexos de tarefas ===
+
+// handleUploadAttachment receives a multipart/form-data body with the file in
+// the "file" field.
+func (r *Router) handleUploadAttachment(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), attachmentTimeout)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	// folga de 1 MiB para os cabeçalhos do multipart
+	if limit := r.attachmentSvc.Limits().MaxBytes; limit > 0 {
+		req.Body = http.MaxBytesReader(w, req.Body, limit+1<<20)
+	}
+	reader, err := req.MultipartReader()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "expected multipart/form-data body")
+		return
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			respondAttachmentError(w, err, "missing file field")
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := r.attachmentSvc.Upload(ctx, workspace.AttachmentInput{
+			TaskID:      taskID,
+			FileName:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			UploadedBy:  current.ID,
+			Body:        part,
+		})
+		part.Close()
+		if err != nil {
+			respondAttachmentError(w, err, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusCreated, attachment)
+		return
+	}
+}
+
+func (r *Router) handleListAttachments(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	attachments, err := r.attachmentSvc.ListAttachments(ctx, taskID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list attachments")
+		return
+	}
+	respondJSON(w, http.StatusOK, attachments)
+}
+
+// handleDownloadAttachment streams the file and sends its checksum in
+// X-Checksum-Sha256.
+func (r *Router) handleDownloadAttachment(w http.ResponseWriter, req *http.Request) {
+	taskID, attachmentID, ok := attachmentParams(w, req)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), attachmentTimeout)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	attachment, err := r.attachmentSvc.GetAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		respondAttachmentError(w, err, err.Error())
+		return
+	}
+	content, err := r.attachmentSvc.Open(ctx, attachment)
+	if err != nil {
+		respondAttachmentError(w, err, "failed to read attachment")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Checksum-Sha256", attachment.Checksum)
+	// o tipo vem do upload; impede o navegador de reinterpretar o conteúdo
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		log.Printf("download attachment %d: %v", attachment.ID, err)
+	}
+}
+
+// handleDeleteAttachment is allowed to the uploader and to whoever manages
+// the project.
+func (r *Router) handleDeleteAttachment(w http.ResponseWriter, req *http.Request) {
+	taskID, attachmentID, ok := attachmentParams(w, req)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), attachmentTimeout)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	attachment, err := r.attachmentSvc.GetAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		respondAttachmentError(w, err, err.Error())
+		return
+	}
+	if attachment.UploadedBy != current.ID && !r.canManageProject(ctx, &task.Project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := r.attachmentSvc.DeleteAttachment(ctx, taskID, attachmentID); err != nil {
+		respondAttachmentError(w, err, "failed to delete attachment")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func attachmentParams(w http.ResponseWriter, req *http.Request) (uint, uint, bool) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return 0, 0, false
+	}
+	attachmentID, err := parseUintParam(req, "attachmentID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid attachment id")
+		return 0, 0, false
+	}
+	return taskID, attachmentID, true
+}
+
+// respondAttachmentError maps known errors and falls back to 400 with the
+// given message; blob store failures are reported as 502.
+func respondAttachmentError(w http.ResponseWriter, err error, fallback string) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, fs.ErrNotExist):
+		respondError(w, http.StatusNotFound, "attachment not found")
+	case errors.As(err, &tooLarge), errors.Is(err, workspace.ErrAttachmentTooLarge):
+		respondError(w, http.StatusRequestEntityTooLarge, workspace.ErrAttachmentTooLarge.Error())
+	case errors.Is(err, workspace.ErrAttachmentTypeNotAllowed):
+		respondError(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, workspace.ErrProjectArchived):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, workspace.ErrBlobStore):
+		log.Printf("attachment storage: %v", err)
+		respondError(w, http.StatusBadGateway, "attachment storage unavailable")
+	default:
+		respondError(w, http.StatusBadRequest, fallback)
+	}
+}
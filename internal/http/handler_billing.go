@@ -0,0 +1,254 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Faturamento ===
+
+func (r *Router) handleCreateBillingRate(w http.ResponseWriter, req *http.Request) {
+	type in struct {
+		ProjectID     *uint   `json:"projectId"`
+		UserID        *uint   `json:"userId"`
+		HourlyRate    float64 `json:"hourlyRate"`
+		Currency      string  `json:"currency"`
+		EffectiveFrom string  `json:"effectiveFrom"`
+		EffectiveTo   *string `json:"effectiveTo"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	from, err := parseTimeISO(body.EffectiveFrom)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid effectiveFrom")
+		return
+	}
+	to, err := parseOptionalTimeISO(body.EffectiveTo)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid effectiveTo")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if body.UserID != nil {
+		if _, err := r.userSvc.GetByID(ctx, *body.UserID); err != nil {
+			respondError(w, http.StatusNotFound, "user not found")
+			return
+		}
+	}
+
+	rate, err := r.billingSvc.CreateRate(ctx, workspace.BillingRateInput{
+		ProjectID:     body.ProjectID,
+		UserID:        body.UserID,
+		HourlyRate:    body.HourlyRate,
+		Currency:      body.Currency,
+		EffectiveFrom: from,
+		EffectiveTo:   to,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "project not found")
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, rate)
+}
+
+func (r *Router) handleListBillingRates(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	var filter workspace.BillingRateFilter
+	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" {
+		if projectID, err := strconv.ParseUint(projectIDStr, 10, 32); err == nil {
+			id := uint(projectID)
+			filter.ProjectID = &id
+		}
+	}
+	if userIDStr := req.URL.Query().Get("userId"); userIDStr != "" {
+		if userID, err := strconv.ParseUint(userIDStr, 10, 32); err == nil {
+			id := uint(userID)
+			filter.UserID = &id
+		}
+	}
+
+	rates, err := r.billingSvc.ListRates(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list billing rates")
+		return
+	}
+	respondJSON(w, http.StatusOK, rates)
+}
+
+func (r *Router) handleDeleteBillingRate(w http.ResponseWriter, req *http.Request) {
+	rateID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid rate id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.billingSvc.DeleteRate(ctx, rateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "rate not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to delete rate")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleCreateInvoice(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	type in struct {
+		PeriodStart string `json:"periodStart"`
+		PeriodEnd   string `json:"periodEnd"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	periodStart, err := parseTimeISO(body.PeriodStart)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid periodStart")
+		return
+	}
+	periodEnd, err := parseTimeISO(body.PeriodEnd)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid periodEnd")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 10*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	invoice, err := r.billingSvc.CreateInvoice(ctx, workspace.InvoiceInput{
+		ProjectID:   projectID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		CreatedBy:   current.ID,
+	})
+	if err != nil {
+		respondInvoiceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, invoice)
+}
+
+func (r *Router) handleListInvoices(w http.ResponseWriter, req *http.Request) {
+	page, pageSize := paginationParams(req)
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	filter := workspace.InvoiceFilter{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" {
+		if projectID, err := strconv.ParseUint(projectIDStr, 10, 32); err == nil {
+			id := uint(projectID)
+			filter.ProjectID = &id
+		}
+	}
+	for _, raw := range req.URL.Query()["status"] {
+		if raw != "" {
+			filter.Status = append(filter.Status, workspace.InvoiceStatus(strings.ToLower(raw)))
+		}
+	}
+
+	result, err := r.billingSvc.ListInvoices(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list invoices")
+		return
+	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) handleGetInvoice(w http.ResponseWriter, req *http.Request) {
+	invoiceID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid invoice id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	invoice, err := r.billingSvc.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		respondInvoiceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, invoice)
+}
+
+func (r *Router) handleIssueInvoice(w http.ResponseWriter, req *http.Request) {
+	r.transitionInvoice(w, req, r.billingSvc.IssueInvoice)
+}
+
+func (r *Router) handlePayInvoice(w http.ResponseWriter, req *http.Request) {
+	r.transitionInvoice(w, req, r.billingSvc.MarkPaid)
+}
+
+func (r *Router) handleVoidInvoice(w http.ResponseWriter, req *http.Request) {
+	r.transitionInvoice(w, req, r.billingSvc.VoidInvoice)
+}
+
+func (r *Router) transitionInvoice(w http.ResponseWriter, req *http.Request, fn func(context.Context, uint) (*workspace.Invoice, error)) {
+	invoiceID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid invoice id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	invoice, err := fn(ctx, invoiceID)
+	if err != nil {
+		respondInvoiceError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, invoice)
+}
+
+func respondInvoiceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, workspace.ErrEntryInvoiced), errors.Is(err, workspace.ErrProjectArchived):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Orçamento do projeto ===
+
+func (r *Router) handleSetProjectBudget(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	type in struct {
+		BudgetHours  float64 `json:"budgetHours"`
+		BudgetAmount float64 `json:"budgetAmount"`
+		Currency     string  `json:"currency"`
+		Thresholds   []int   `json:"thresholds"`
+		HardBudget   bool    `json:"hardBudget"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	updated, err := r.projectSvc.SetBudget(ctx, projectID, workspace.BudgetInput{
+		Hours:      body.BudgetHours,
+		Amount:     body.BudgetAmount,
+		Currency:   body.Currency,
+		Thresholds: body.Thresholds,
+		Hard:       body.HardBudget,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "project not found")
+		} else if errors.Is(err, workspace.ErrProjectArchived) {
+			respondError(w, http.StatusConflict, err.Error())
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+func (r *Router) handleGetProjectBurn(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	burn, err := r.projectSvc.Burn(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to compute burn")
+		return
+	}
+	respondJSON(w, http.StatusOK, burn)
+}
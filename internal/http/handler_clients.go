@@ -0,0 +1,194 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Clientes ===
+
+type clientContactBody struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+	Role      string `json:"role"`
+	IsPrimary bool   `json:"isPrimary"`
+}
+
+type clientBody struct {
+	Name           string              `json:"name"`
+	LegalName      string              `json:"legalName"`
+	TaxID          string              `json:"taxId"`
+	BillingEmail   string              `json:"billingEmail"`
+	BillingAddress string              `json:"billingAddress"`
+	Currency       string              `json:"currency"`
+	Notes          string              `json:"notes"`
+	Active         *bool               `json:"active"`
+	Contacts       []clientContactBody `json:"contacts"`
+}
+
+func (b clientBody) input() workspace.ClientInput {
+	in := workspace.ClientInput{
+		Name:           b.Name,
+		LegalName:      b.LegalName,
+		TaxID:          b.TaxID,
+		BillingEmail:   b.BillingEmail,
+		BillingAddress: b.BillingAddress,
+		Currency:       b.Currency,
+		Notes:          b.Notes,
+		Active:         b.Active == nil || *b.Active,
+		Contacts:       make([]workspace.ClientContactInput, len(b.Contacts)),
+	}
+	for i, c := range b.Contacts {
+		in.Contacts[i] = workspace.ClientContactInput{
+			Name:      c.Name,
+			Email:     c.Email,
+			Phone:     c.Phone,
+			Role:      c.Role,
+			IsPrimary: c.IsPrimary,
+		}
+	}
+	return in
+}
+
+func (r *Router) handleCreateClient(w http.ResponseWriter, req *http.Request) {
+	var body clientBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	client, err := r.clientSvc.CreateClient(ctx, body.input())
+	if err != nil {
+		respondClientError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, client)
+}
+
+func (r *Router) handleListClients(w http.ResponseWriter, req *http.Request) {
+	page, pageSize := paginationParams(req)
+	filter := workspace.ClientFilter{
+		Search:   req.URL.Query().Get("search"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	if active, err := strconv.ParseBool(req.URL.Query().Get("active")); err == nil {
+		filter.Active = &active
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.clientSvc.ListClients(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list clients")
+		return
+	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) handleGetClient(w http.ResponseWriter, req *http.Request) {
+	clientID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	client, err := r.clientSvc.GetClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "client not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load client")
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, client)
+}
+
+func (r *Router) handleUpdateClient(w http.ResponseWriter, req *http.Request) {
+	clientID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+	var body clientBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	client, err := r.clientSvc.UpdateClient(ctx, clientID, body.input())
+	if err != nil {
+		respondClientError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, client)
+}
+
+func (r *Router) handleDeleteClient(w http.ResponseWriter, req *http.Request) {
+	clientID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.clientSvc.DeleteClient(ctx, clientID); err != nil {
+		respondClientError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleClientSummary(w http.ResponseWriter, req *http.Request) {
+	clientID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	summary, err := r.clientSvc.Summary(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "client not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "unable to build client summary")
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, summary)
+}
+
+func respondClientError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "client not found")
+	case errors.Is(err, workspace.ErrClientNameTaken), errors.Is(err, workspace.ErrClientHasProjects):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
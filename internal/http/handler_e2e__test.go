@@ -5,12 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/v-Kaefer/Const-Software-25-02/internal/auth"
 	httpapi "github.com/v-Kaefer/Const-Software-25-02/internal/http"
+	"github.com/v-Kaefer/Const-Software-25-02/internal/storage"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/timeimport"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
 	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
 
@@ -20,14 +24,22 @@ import (
 
 func newTestServer(t *testing.T) *httptest.Server {
 	t.Helper()
+	ts, _ := newTestServerWithAuth(t, httpapi.NewMockAuthMiddleware())
+	return ts
+}
+
+// newTestServerWithAuth monta o servidor com o middleware informado e devolve
+// também o banco, para semear dados que a API não cria para qualquer papel.
+func newTestServerWithAuth(t *testing.T, authMiddleware *auth.Middleware) (*httptest.Server, *gorm.DB) {
+	t.Helper()
 
 	// DB SQLite em memória para testes (rápido e isolado)
 	dsn := fmt.Sprintf("file:testdb_%d?mode=memory&cache=shared", time.Now().UnixNano())
-	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		t.Fatalf("gorm open: %v", err)
 	}
-	if err := db.AutoMigrate(&user.User{}, &workspace.Project{}, &workspace.Task{}, &workspace.TimeEntry{}); err != nil {
+	if err := db.AutoMigrate(&user.User{}, &workspace.Project{}, &workspace.Task{}, &workspace.TimeEntry{}, &workspace.Timesheet{}, &workspace.Timer{}, &workspace.HourPolicy{}, &workspace.BillingRate{}, &workspace.Invoice{}, &workspace.InvoiceLine{}, &workspace.PeriodLock{}, &workspace.ApprovalDelegation{}, &workspace.ProjectMember{}, &workspace.ProjectStatusChange{}, &workspace.Client{}, &workspace.ClientContact{}, &workspace.Milestone{}, &workspace.ProjectTemplate{}, &workspace.ProjectTemplateMilestone{}, &workspace.ProjectTemplateTask{}, &workspace.Tag{}, &workspace.ProjectTag{}, &workspace.TaskTag{}, &workspace.TaskDependency{}, &workspace.TaskComment{}, &workspace.TaskCommentRevision{}, &workspace.TaskAttachment{}); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
 
@@ -36,15 +48,30 @@ func newTestServer(t *testing.T) *httptest.Server {
 	if _, err := svc.Register(context.Background(), "test-user", "Mock Admin"); err != nil {
 		t.Fatalf("seed admin user: %v", err)
 	}
+	if err := db.Create(&workspace.Client{Name: "ACME", Currency: "BRL", Active: true}).Error; err != nil {
+		t.Fatalf("seed client: %v", err)
+	}
 	projectSvc := workspace.NewProjectService(db)
 	taskSvc := workspace.NewTaskService(db)
 	timeSvc := workspace.NewTimeEntryService(db)
+	timesheetSvc := workspace.NewTimesheetService(db, timeSvc)
+	timerSvc := workspace.NewTimerService(db, timeSvc, 12*time.Hour)
+	billingSvc := workspace.NewBillingService(db)
+	reportSvc := workspace.NewReportService(db)
+	importSvc := timeimport.NewService(db, timeSvc)
+	periodSvc := workspace.NewPeriodLockService(db)
+	delegationSvc := workspace.NewDelegationService(db)
+	clientSvc := workspace.NewClientService(db)
+	tagSvc := workspace.NewTagService(db)
+	blobStore, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+	attachmentSvc := workspace.NewAttachmentService(db, blobStore, workspace.AttachmentLimits{MaxBytes: 1 << 20})
 
-	// Create a mock auth middleware for testing (empty config is fine for tests without actual auth)
-	mockAuthMiddleware := httpapi.NewMockAuthMiddleware()
-	router := httpapi.NewRouter(svc, projectSvc, taskSvc, timeSvc, mockAuthMiddleware)
+	router := httpapi.NewRouter(svc, projectSvc, taskSvc, timeSvc, timesheetSvc, timerSvc, billingSvc, reportSvc, importSvc, periodSvc, delegationSvc, clientSvc, tagSvc, attachmentSvc, authMiddleware)
 
-	return httptest.NewServer(router)
+	return httptest.NewServer(router), db
 }
 
 func TestHTTP_CreateAndGetUser(t *testing.T) {
@@ -187,4 +214,203 @@ func TestHTTP_ProjectTaskTimeEntryFlow(t *testing.T) {
 	if approved.ApprovedAt == nil {
 		t.Fatal("expected approvedAt timestamp")
 	}
+
+	for status, want := range map[string]int{"Approved": http.StatusOK, "late": http.StatusBadRequest} {
+		listResp, err := http.Get(ts.URL + "/api/v1/time-entries?status=" + status)
+		if err != nil {
+			t.Fatalf("GET /api/v1/time-entries: %v", err)
+		}
+		listResp.Body.Close()
+		if listResp.StatusCode != want {
+			t.Fatalf("list with status %q = %d, want %d", status, listResp.StatusCode, want)
+		}
+	}
+}
+
+func TestHTTP_ListProjectsCSVNegotiation(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	start := time.Now().UTC().Format(time.RFC3339)
+	for _, name := range []string{"Alpha", "Beta"} {
+		payload := fmt.Sprintf(`{"name":"%s","clientName":"ACME","description":"Desc","startDate":"%s"}`, name, start)
+		resp, err := http.Post(ts.URL+"/api/v1/projects", "application/json", bytes.NewReader([]byte(payload)))
+		if err != nil {
+			t.Fatalf("POST /api/v1/projects: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("POST project status = %d", resp.StatusCode)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/api/v1/projects?columns=name,client&pageSize=1", nil)
+	if err != nil {
+		t.Fatalf("create request: %v", err)
+	}
+	req.Header.Set("Accept", "text/csv")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET projects csv: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("csv status = %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(resp.Body); err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	// exportação ignora a paginação e traz todas as linhas
+	if body.String() != "Name,Client\nAlpha,ACME\nBeta,ACME\n" {
+		t.Fatalf("unexpected csv body %q", body.String())
+	}
+}
+
+func TestHTTP_TaskAttachmentUploadAndDownload(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Close()
+
+	// o usuário do middleware de teste precisa existir para registrar o autor
+	userResp, err := http.Post(ts.URL+"/api/v1/users", "application/json", bytes.NewReader([]byte(`{"email":"test-user","name":"Test"}`)))
+	if err != nil {
+		t.Fatalf("POST /api/v1/users: %v", err)
+	}
+	userResp.Body.Close()
+
+	start := time.Now().UTC().Format(time.RFC3339)
+	projectResp, err := http.Post(ts.URL+"/api/v1/projects", "application/json", bytes.NewReader([]byte(fmt.Sprintf(`{"name":"Docs","clientName":"ACME","startDate":"%s"}`, start))))
+	if err != nil {
+		t.Fatalf("POST /api/v1/projects: %v", err)
+	}
+	defer projectResp.Body.Close()
+	var project workspace.Project
+	if err := json.NewDecoder(projectResp.Body).Decode(&project); err != nil {
+		t.Fatalf("decode project: %v", err)
+	}
+	taskResp, err := http.Post(fmt.Sprintf("%s/api/v1/projects/%d/tasks", ts.URL, project.ID), "application/json", bytes.NewReader([]byte(`{"title":"Spec","assigneeId":1}`)))
+	if err != nil {
+		t.Fatalf("POST task: %v", err)
+	}
+	defer taskResp.Body.Close()
+	var task workspace.Task
+	if err := json.NewDecoder(taskResp.Body).Decode(&task); err != nil {
+		t.Fatalf("decode task: %v", err)
+	}
+
+	upload := func(content []byte) *http.Response {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", "spec.txt")
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		part.Write(content)
+		form.Close()
+		resp, err := http.Post(fmt.Sprintf("%s/api/v1/tasks/%d/attachments", ts.URL, task.ID), form.FormDataContentType(), &body)
+		if err != nil {
+			t.Fatalf("POST attachment: %v", err)
+		}
+		return resp
+	}
+
+	resp := upload([]byte("primeira versão"))
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("upload status = %d", resp.StatusCode)
+	}
+	var attachment workspace.TaskAttachment
+	if err := json.NewDecoder(resp.Body).Decode(&attachment); err != nil {
+		t.Fatalf("decode attachment: %v", err)
+	}
+
+	tooLarge := upload(bytes.Repeat([]byte("a"), 1<<20+1))
+	tooLarge.Body.Close()
+	if tooLarge.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized upload status = %d, want 413", tooLarge.StatusCode)
+	}
+
+	download, err := http.Get(fmt.Sprintf("%s/api/v1/tasks/%d/attachments/%d", ts.URL, task.ID, attachment.ID))
+	if err != nil {
+		t.Fatalf("GET attachment: %v", err)
+	}
+	defer download.Body.Close()
+	var content bytes.Buffer
+	if _, err := content.ReadFrom(download.Body); err != nil {
+		t.Fatalf("read attachment: %v", err)
+	}
+	if download.StatusCode != http.StatusOK || content.String() != "primeira versão" {
+		t.Fatalf("unexpected download: %d %q", download.StatusCode, content.String())
+	}
+	if download.Header.Get("X-Checksum-Sha256") != attachment.Checksum || download.Header.Get("Content-Disposition") != `attachment; filename=spec.txt` ||
+		download.Header.Get("X-Content-Type-Options") != "nosniff" {
+		t.Fatalf("unexpected download headers: %v", download.Header)
+	}
+}
+
+func TestHTTP_OverrideDependenciesRequiresManager(t *testing.T) {
+	ts, db := newTestServerWithAuth(t, httpapi.NewMockAuthMiddlewareAs("dev@example.com", auth.RoleUser))
+	defer ts.Close()
+
+	ctx := context.Background()
+	dev, err := user.NewService(db, user.NewRepo(db)).Register(ctx, "dev@example.com", "Dev")
+	if err != nil {
+		t.Fatalf("register dev: %v", err)
+	}
+	projectSvc := workspace.NewProjectService(db)
+	taskSvc := workspace.NewTaskService(db)
+	project, err := projectSvc.CreateProject(ctx, workspace.ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: time.Now().UTC(), OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	predecessor, err := taskSvc.CreateTask(ctx, workspace.TaskInput{ProjectID: project.ID, Title: "Modelagem", AssigneeID: 1})
+	if err != nil {
+		t.Fatalf("create predecessor: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, workspace.TaskInput{ProjectID: project.ID, Title: "API", AssigneeID: dev.ID})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := taskSvc.AddDependency(ctx, task.ID, predecessor.ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	start := func() *http.Response {
+		body := []byte(`{"title":"API","status":"in_progress","assigneeId":` + fmt.Sprint(dev.ID) + `,"overrideDependencies":true}`)
+		req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/tasks/%d", ts.URL, task.ID), bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("build request: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("PUT task: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if _, err := projectSvc.AddMember(ctx, project.ID, dev.ID, workspace.ProjectContributor, 1); err != nil {
+		t.Fatalf("add contributor: %v", err)
+	}
+	if resp := start(); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("contributor override status = %d, want 403", resp.StatusCode)
+	}
+	unchanged, err := taskSvc.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if unchanged.Status != workspace.TaskTodo {
+		t.Fatalf("expected task to stay todo, got %s", unchanged.Status)
+	}
+
+	if _, err := projectSvc.AddMember(ctx, project.ID, dev.ID, workspace.ProjectManager, 1); err != nil {
+		t.Fatalf("promote to manager: %v", err)
+	}
+	if resp := start(); resp.StatusCode != http.StatusOK {
+		t.Fatalf("manager override status = %d, want 200", resp.StatusCode)
+	}
 }
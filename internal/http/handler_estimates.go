@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// === Handlers: Estimativas ===
+
+func (r *Router) handleTaskEstimate(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	estimate, err := r.taskSvc.Estimate(ctx, taskID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to compare estimate")
+		return
+	}
+	respondJSON(w, http.StatusOK, estimate)
+}
+
+// handleEstimateVarianceReport groups the estimate variance per project and
+// per assignee, filtered by projectId, assigneeId and status.
+func (r *Router) handleEstimateVarianceReport(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	filter := workspace.EstimateVarianceFilter{Status: taskStatusesFromQuery(query["status"])}
+	for key, target := range map[string]**uint{
+		"projectId":  &filter.ProjectID,
+		"assigneeId": &filter.AssigneeID,
+	} {
+		if value := query.Get(key); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid "+key)
+				return
+			}
+			v := uint(id)
+			*target = &v
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 10*time.Second)
+	defer cancel()
+
+	// mesmo escopo da listagem de tarefas: admin vê tudo, demais as tarefas
+	// atribuídas a eles e as dos projetos que possuem ou de que participam
+	if !r.isAdmin(ctx) {
+		current, err := r.currentUser(ctx)
+		if err != nil {
+			respondError(w, http.StatusForbidden, "user not registered in system")
+			return
+		}
+		id := current.ID
+		filter.VisibleTo = &id
+	}
+
+	report, err := r.reportSvc.EstimateVariance(ctx, filter)
+	if err != nil {
+		respondReportError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
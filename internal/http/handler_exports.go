@@ -0,0 +1,209 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/internal/export"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// exportTimeout bounds a full export, which may stream many batches.
+const exportTimeout = 2 * time.Minute
+
+var timeEntryExportColumns = []export.Column[workspace.TimeEntry]{
+	{Key: "id", Header: "ID", Value: func(e workspace.TimeEntry) any { return e.ID }},
+	{Key: "date", Header: "Date", Value: func(e workspace.TimeEntry) any { return e.EntryDate }},
+	{Key: "userId", Header: "User ID", Value: func(e workspace.TimeEntry) any { return e.UserID }},
+	{Key: "taskId", Header: "Task ID", Value: func(e workspace.TimeEntry) any { return e.TaskID }},
+	{Key: "hours", Header: "Hours", Value: func(e workspace.TimeEntry) any { return e.Hours }},
+	{Key: "status", Header: "Status", Value: func(e workspace.TimeEntry) any { return e.Status }},
+	{Key: "notes", Header: "Notes", Value: func(e workspace.TimeEntry) any { return e.Notes }},
+	{Key: "approvedAt", Header: "Approved At", Value: func(e workspace.TimeEntry) any { return e.ApprovedAt }},
+	{Key: "approvedBy", Header: "Approved By", Value: func(e workspace.TimeEntry) any { return e.ApprovedBy }},
+	{Key: "invoiceId", Header: "Invoice ID", Value: func(e workspace.TimeEntry) any { return e.InvoiceID }},
+	{Key: "createdAt", Header: "Created At", Value: func(e workspace.TimeEntry) any { return e.CreatedAt }},
+}
+
+var timeEntryDefaultColumns = []string{"id", "date", "userId", "taskId", "hours", "status", "notes"}
+
+var taskExportColumns = []export.Column[workspace.Task]{
+	{Key: "id", Header: "ID", Value: func(t workspace.Task) any { return t.ID }},
+	{Key: "projectId", Header: "Project ID", Value: func(t workspace.Task) any { return t.ProjectID }},
+	{Key: "project", Header: "Project", Value: func(t workspace.Task) any { return t.Project.Name }},
+	{Key: "title", Header: "Title", Value: func(t workspace.Task) any { return t.Title }},
+	{Key: "description", Header: "Description", Value: func(t workspace.Task) any { return t.Description }},
+	{Key: "status", Header: "Status", Value: func(t workspace.Task) any { return t.Status }},
+	{Key: "assigneeId", Header: "Assignee ID", Value: func(t workspace.Task) any { return t.AssigneeID }},
+	{Key: "dueDate", Header: "Due Date", Value: func(t workspace.Task) any { return t.DueDate }},
+	{Key: "estimateHours", Header: "Estimate Hours", Value: func(t workspace.Task) any { return t.EstimateHours }},
+	{Key: "remainingEstimateHours", Header: "Remaining Estimate Hours", Value: func(t workspace.Task) any { return t.RemainingEstimateHours }},
+	{Key: "milestoneId", Header: "Milestone ID", Value: func(t workspace.Task) any { return t.MilestoneID }},
+	{Key: "parentId", Header: "Parent ID", Value: func(t workspace.Task) any { return t.ParentID }},
+	{Key: "createdAt", Header: "Created At", Value: func(t workspace.Task) any { return t.CreatedAt }},
+}
+
+var taskDefaultColumns = []string{"id", "projectId", "project", "title", "status", "assigneeId", "dueDate"}
+
+var projectExportColumns = []export.Column[workspace.Project]{
+	{Key: "id", Header: "ID", Value: func(p workspace.Project) any { return p.ID }},
+	{Key: "name", Header: "Name", Value: func(p workspace.Project) any { return p.Name }},
+	{Key: "client", Header: "Client", Value: func(p workspace.Project) any { return p.ClientName }},
+	{Key: "description", Header: "Description", Value: func(p workspace.Project) any { return p.Description }},
+	{Key: "status", Header: "Status", Value: func(p workspace.Project) any { return p.Status }},
+	{Key: "ownerId", Header: "Owner ID", Value: func(p workspace.Project) any { return p.OwnerID }},
+	{Key: "startDate", Header: "Start Date", Value: func(p workspace.Project) any { return p.StartDate }},
+	{Key: "endDate", Header: "End Date", Value: func(p workspace.Project) any { return p.EndDate }},
+	{Key: "budgetHours", Header: "Budget Hours", Value: func(p workspace.Project) any { return p.BudgetHours }},
+	{Key: "budgetAmount", Header: "Budget Amount", Value: func(p workspace.Project) any { return p.BudgetAmount }},
+	{Key: "createdAt", Header: "Created At", Value: func(p workspace.Project) any { return p.CreatedAt }},
+}
+
+var projectDefaultColumns = []string{"id", "name", "client", "status", "ownerId", "startDate", "endDate"}
+
+// === Handlers: Exportação ===
+
+func (r *Router) handleExportTimeEntries(w http.ResponseWriter, req *http.Request) {
+	format, ok := exportFormatFromQuery(w, req)
+	if !ok {
+		return
+	}
+	filter, err := r.timeEntryFilterFromRequest(req.Context(), req)
+	if err != nil {
+		respondTimeEntryFilterError(w, err)
+		return
+	}
+	r.exportTimeEntries(w, req, filter, format)
+}
+
+func (r *Router) handleExportTasks(w http.ResponseWriter, req *http.Request) {
+	format, ok := exportFormatFromQuery(w, req)
+	if !ok {
+		return
+	}
+	filter, err := r.taskFilterFromRequest(req.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	r.exportTasks(w, req, filter, format)
+}
+
+func (r *Router) handleExportProjects(w http.ResponseWriter, req *http.Request) {
+	format, ok := exportFormatFromQuery(w, req)
+	if !ok {
+		return
+	}
+	filter, err := r.projectFilterFromRequest(req.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	r.exportProjects(w, req, filter, format)
+}
+
+func (r *Router) exportTimeEntries(w http.ResponseWriter, req *http.Request, filter workspace.TimeEntryFilter, format export.Format) {
+	columns, err := export.SelectColumns(timeEntryExportColumns, timeEntryDefaultColumns, exportColumnsFromQuery(req))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	streamExport(w, req, format, "time-entries", columns, func(ctx context.Context, fn func([]workspace.TimeEntry) error) error {
+		return r.timeSvc.EachEntry(ctx, filter, fn)
+	})
+}
+
+func (r *Router) exportTasks(w http.ResponseWriter, req *http.Request, filter workspace.TaskFilter, format export.Format) {
+	columns, err := export.SelectColumns(taskExportColumns, taskDefaultColumns, exportColumnsFromQuery(req))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	streamExport(w, req, format, "tasks", columns, func(ctx context.Context, fn func([]workspace.Task) error) error {
+		return r.taskSvc.EachTask(ctx, filter, fn)
+	})
+}
+
+func (r *Router) exportProjects(w http.ResponseWriter, req *http.Request, filter workspace.ProjectFilter, format export.Format) {
+	columns, err := export.SelectColumns(projectExportColumns, projectDefaultColumns, exportColumnsFromQuery(req))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	streamExport(w, req, format, "projects", columns, func(ctx context.Context, fn func([]workspace.Project) error) error {
+		return r.projectSvc.EachProject(ctx, filter, fn)
+	})
+}
+
+// streamExport writes the full result set batch by batch. Once the first
+// byte is sent the status can no longer change, so later failures are logged
+// and the download is cut short.
+func streamExport[T any](w http.ResponseWriter, req *http.Request, format export.Format, name string, columns []export.Column[T], each func(context.Context, func([]T) error) error) {
+	ctx, cancel := context.WithTimeout(req.Context(), exportTimeout)
+	defer cancel()
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().UTC().Format("20060102"), format))
+
+	var (
+		out export.Writer
+		err error
+	)
+	if format == export.FormatXLSX {
+		out, err = export.NewXLSXWriter(w, name)
+	} else {
+		out = export.NewCSVWriter(w, exportLocale(req))
+	}
+	if err == nil {
+		err = out.WriteHeader(export.Headers(columns))
+	}
+	if err == nil {
+		err = each(ctx, func(batch []T) error {
+			for _, item := range batch {
+				if err := out.WriteRow(export.Row(columns, item)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		log.Printf("export %s: %v", name, err)
+	}
+}
+
+func exportFormatFromQuery(w http.ResponseWriter, req *http.Request) (export.Format, bool) {
+	format, err := export.ParseFormat(req.URL.Query().Get("format"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return format, true
+}
+
+func exportColumnsFromQuery(req *http.Request) []string {
+	var columns []string
+	for _, raw := range req.URL.Query()["columns"] {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				columns = append(columns, part)
+			}
+		}
+	}
+	return columns
+}
+
+// exportLocale prefers ?locale= and falls back to Accept-Language.
+func exportLocale(req *http.Request) export.Locale {
+	if locale := req.URL.Query().Get("locale"); locale != "" {
+		return export.ParseLocale(locale)
+	}
+	return export.ParseLocale(req.Header.Get("Accept-Language"))
+}
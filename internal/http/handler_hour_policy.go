@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// === Handlers: Política de horas ===
+
+func (r *Router) handleGetHourPolicy(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if !r.isAdminOrOwner(ctx, userID) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	policy, err := r.timeSvc.EffectivePolicy(ctx, userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load hour policy")
+		return
+	}
+	respondJSON(w, http.StatusOK, policy)
+}
+
+func (r *Router) handleSetHourPolicy(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	type in struct {
+		MaxDailyHours       float64 `json:"maxDailyHours"`
+		MaxWeeklyHours      float64 `json:"maxWeeklyHours"`
+		DailyOvertimeHours  float64 `json:"dailyOvertimeHours"`
+		WeeklyOvertimeHours float64 `json:"weeklyOvertimeHours"`
+		WeekendRule         string  `json:"weekendRule"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if _, err := r.userSvc.GetByID(ctx, userID); err != nil {
+		respondError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	policy, err := r.timeSvc.SetUserPolicy(ctx, userID, workspace.HourPolicy{
+		MaxDailyHours:       body.MaxDailyHours,
+		MaxWeeklyHours:      body.MaxWeeklyHours,
+		DailyOvertimeHours:  body.DailyOvertimeHours,
+		WeeklyOvertimeHours: body.WeeklyOvertimeHours,
+		WeekendRule:         workspace.WeekendRule(strings.ToLower(body.WeekendRule)),
+	})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, policy)
+}
+
+func (r *Router) handleResetHourPolicy(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.timeSvc.ResetUserPolicy(ctx, userID); err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to reset hour policy")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/timeimport"
+)
+
+const (
+	// maxImportBytes bounds the uploaded CSV.
+	maxImportBytes = 10 << 20
+	importTimeout  = time.Minute
+)
+
+// === Handlers: Importação ===
+
+// handleImportTimeEntries receives a raw CSV export in the body. With
+// dryRun=true the rows are validated and reported but nothing is stored.
+func (r *Router) handleImportTimeEntries(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	source, err := timeimport.ParseSource(query.Get("source"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	dryRun := false
+	if raw := query.Get("dryRun"); raw != "" {
+		dryRun, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid dryRun")
+			return
+		}
+	}
+
+	// importações grandes podem levar mais que o timeout padrão
+	ctx, cancel := context.WithTimeout(req.Context(), importTimeout)
+	defer cancel()
+
+	body := http.MaxBytesReader(w, req.Body, maxImportBytes)
+	report, err := r.importSvc.Import(ctx, body, timeimport.Options{Source: source, DryRun: dryRun})
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondError(w, http.StatusRequestEntityTooLarge, "import file too large")
+			return
+		}
+		if errors.Is(err, timeimport.ErrInvalidFile) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		log.Printf("import time entries: %v", err)
+		respondError(w, http.StatusInternalServerError, "failed to import time entries")
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
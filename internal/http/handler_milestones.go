@@ -0,0 +1,213 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Marcos ===
+
+type milestoneBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TargetDate  string `json:"targetDate"`
+	Status      string `json:"status"`
+}
+
+func (b milestoneBody) input() (workspace.MilestoneInput, error) {
+	target, err := parseTimeISO(b.TargetDate)
+	if err != nil {
+		return workspace.MilestoneInput{}, errors.New("invalid targetDate")
+	}
+	return workspace.MilestoneInput{
+		Name:        b.Name,
+		Description: b.Description,
+		TargetDate:  target,
+		Status:      workspace.MilestoneStatus(strings.ToLower(b.Status)),
+	}, nil
+}
+
+func (r *Router) handleCreateMilestone(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	var body milestoneBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	in, err := body.input()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	milestone, err := r.projectSvc.CreateMilestone(ctx, projectID, in)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, milestone)
+}
+
+func (r *Router) handleListMilestones(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	milestones, err := r.projectSvc.ListMilestones(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list milestones")
+		return
+	}
+	respondJSON(w, http.StatusOK, milestones)
+}
+
+func (r *Router) handleGetMilestone(w http.ResponseWriter, req *http.Request) {
+	milestoneID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid milestone id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	milestone, project, ok := r.loadMilestone(ctx, w, milestoneID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	respondJSON(w, http.StatusOK, milestone)
+}
+
+func (r *Router) handleUpdateMilestone(w http.ResponseWriter, req *http.Request) {
+	milestoneID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid milestone id")
+		return
+	}
+	var body milestoneBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	in, err := body.input()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	_, project, ok := r.loadMilestone(ctx, w, milestoneID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	updated, err := r.projectSvc.UpdateMilestone(ctx, milestoneID, in)
+	if err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+func (r *Router) handleDeleteMilestone(w http.ResponseWriter, req *http.Request) {
+	milestoneID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid milestone id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	_, project, ok := r.loadMilestone(ctx, w, milestoneID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := r.projectSvc.DeleteMilestone(ctx, milestoneID); err != nil {
+		respondMilestoneError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadMilestone fetches a milestone and its project, writing 404/500 when
+// either cannot be loaded.
+func (r *Router) loadMilestone(ctx context.Context, w http.ResponseWriter, milestoneID uint) (*workspace.MilestoneWithProgress, *workspace.Project, bool) {
+	milestone, err := r.projectSvc.GetMilestone(ctx, milestoneID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "milestone not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load milestone")
+		}
+		return nil, nil, false
+	}
+	project, ok := r.loadProject(ctx, w, milestone.ProjectID)
+	if !ok {
+		return nil, nil, false
+	}
+	return milestone, project, true
+}
+
+func respondMilestoneError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "milestone not found")
+	case errors.Is(err, workspace.ErrProjectArchived):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Fechamento de períodos ===
+
+func (r *Router) handleClosePeriod(w http.ResponseWriter, req *http.Request) {
+	type in struct {
+		ProjectID   *uint  `json:"projectId"`
+		PeriodStart string `json:"periodStart"`
+		PeriodEnd   string `json:"periodEnd"`
+		Reason      string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	periodStart, err := parseTimeISO(body.PeriodStart)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid periodStart")
+		return
+	}
+	periodEnd, err := parseTimeISO(body.PeriodEnd)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid periodEnd")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	lock, err := r.periodSvc.ClosePeriod(ctx, workspace.PeriodCloseInput{
+		ProjectID:   body.ProjectID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Reason:      body.Reason,
+		ClosedBy:    current.ID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "project not found")
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, lock)
+}
+
+func (r *Router) handleListPeriodLocks(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	var filter workspace.PeriodLockFilter
+	if projectIDStr := req.URL.Query().Get("projectId"); projectIDStr != "" {
+		if projectID, err := strconv.ParseUint(projectIDStr, 10, 32); err == nil {
+			id := uint(projectID)
+			filter.ProjectID = &id
+		}
+	}
+	if activeStr := req.URL.Query().Get("active"); activeStr != "" {
+		if active, err := strconv.ParseBool(activeStr); err == nil {
+			filter.Active = &active
+		}
+	}
+
+	locks, err := r.periodSvc.ListLocks(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list period locks")
+		return
+	}
+	respondJSON(w, http.StatusOK, locks)
+}
+
+func (r *Router) handleReopenPeriod(w http.ResponseWriter, req *http.Request) {
+	lockID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid period lock id")
+		return
+	}
+	type in struct {
+		Reason string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	lock, err := r.periodSvc.ReopenPeriod(ctx, lockID, current.ID, body.Reason)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "period lock not found")
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, lock)
+}
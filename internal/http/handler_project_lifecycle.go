@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Ciclo de vida do projeto ===
+
+func (r *Router) handleTransitionProject(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	type in struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	updated, err := r.projectSvc.TransitionProject(ctx, projectID, workspace.ProjectTransitionInput{
+		Status:    workspace.ProjectStatus(strings.ToLower(body.Status)),
+		Reason:    body.Reason,
+		ChangedBy: current.ID,
+	})
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+func (r *Router) handleProjectStatusHistory(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	history, err := r.projectSvc.StatusHistory(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to load status history")
+		return
+	}
+	respondJSON(w, http.StatusOK, history)
+}
+
+// respondProjectError maps domain errors raised while changing a project;
+// blocked transitions return the list of blockers.
+func respondProjectError(w http.ResponseWriter, err error) {
+	var blocked *workspace.TransitionBlockedError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "project not found")
+	case errors.Is(err, workspace.ErrProjectArchived), errors.Is(err, workspace.ErrClientInactive):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.As(err, &blocked):
+		respondJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":    err.Error(),
+			"blockers": blocked.Blockers,
+		})
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
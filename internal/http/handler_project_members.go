@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Membros do projeto ===
+
+func (r *Router) handleListProjectMembers(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	members, err := r.projectSvc.ListMembers(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list members")
+		return
+	}
+	respondJSON(w, http.StatusOK, members)
+}
+
+func (r *Router) handleSetProjectMember(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	userID, err := parseUintParam(req, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	type in struct {
+		Role string `json:"role"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	role, err := workspace.ParseProjectRole(body.Role)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	if _, err := r.userSvc.GetByID(ctx, userID); err != nil {
+		respondError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	member, err := r.projectSvc.AddMember(ctx, projectID, userID, role, current.ID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, member)
+}
+
+func (r *Router) handleRemoveProjectMember(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	userID, err := parseUintParam(req, "userID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := r.projectSvc.RemoveMember(ctx, projectID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "member not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to remove member")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadProject writes 404/500 and returns false when the project cannot be
+// loaded.
+func (r *Router) loadProject(ctx context.Context, w http.ResponseWriter, projectID uint) (*workspace.Project, bool) {
+	project, err := r.projectSvc.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "project not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load project")
+		}
+		return nil, false
+	}
+	return project, true
+}
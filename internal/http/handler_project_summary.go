@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// === Handlers: Painel do projeto ===
+
+func (r *Router) handleProjectSummary(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	now := time.Now().UTC()
+	tasks, err := r.taskSvc.ProjectTaskCounts(ctx, projectID, now)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to build project summary")
+		return
+	}
+	hours, err := r.timeSvc.ProjectHours(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to build project summary")
+		return
+	}
+	respondJSON(w, http.StatusOK, workspace.SummarizeProject(project, tasks, hours, now))
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Modelos e cópia de projetos ===
+
+// projectCopyBody is the payload of the project created from a template or
+// clone; the authenticated user becomes its owner.
+type projectCopyBody struct {
+	Name          string  `json:"name"`
+	ClientID      *uint   `json:"clientId"`
+	ClientName    string  `json:"clientName"`
+	Description   string  `json:"description"`
+	StartDate     string  `json:"startDate"`
+	EndDate       *string `json:"endDate"`
+	CopyAssignees bool    `json:"copyAssignees"`
+}
+
+func (b projectCopyBody) input(ownerID uint) (workspace.ProjectCopyInput, error) {
+	start, err := parseTimeISO(b.StartDate)
+	if err != nil {
+		return workspace.ProjectCopyInput{}, errors.New("invalid startDate")
+	}
+	endTime, err := parseOptionalTimeISO(b.EndDate)
+	if err != nil {
+		return workspace.ProjectCopyInput{}, errors.New("invalid endDate")
+	}
+	return workspace.ProjectCopyInput{
+		Project: workspace.ProjectInput{
+			Name:        b.Name,
+			ClientID:    b.ClientID,
+			ClientName:  b.ClientName,
+			Description: b.Description,
+			StartDate:   start,
+			EndDate:     endTime,
+			OwnerID:     ownerID,
+		},
+		CopyAssignees: b.CopyAssignees,
+	}, nil
+}
+
+func (r *Router) handleSaveProjectTemplate(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	type in struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	template, err := r.projectSvc.SaveAsTemplate(ctx, projectID, workspace.ProjectTemplateInput{
+		Name:        body.Name,
+		Description: body.Description,
+		CreatedBy:   current.ID,
+	})
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, template)
+}
+
+func (r *Router) handleCloneProject(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	var body projectCopyBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	in, err := body.input(current.ID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	clone, err := r.projectSvc.CloneProject(ctx, projectID, in)
+	if err != nil {
+		respondProjectError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, clone)
+}
+
+func (r *Router) handleListProjectTemplates(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	templates, err := r.projectSvc.ListTemplates(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list templates")
+		return
+	}
+	respondJSON(w, http.StatusOK, templates)
+}
+
+func (r *Router) handleGetProjectTemplate(w http.ResponseWriter, req *http.Request) {
+	templateID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid template id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	template, err := r.projectSvc.GetTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "template not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load template")
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, template)
+}
+
+func (r *Router) handleDeleteProjectTemplate(w http.ResponseWriter, req *http.Request) {
+	templateID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid template id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.projectSvc.DeleteTemplate(ctx, templateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "template not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to delete template")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleCreateProjectFromTemplate(w http.ResponseWriter, req *http.Request) {
+	templateID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid template id")
+		return
+	}
+	var body projectCopyBody
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	in, err := body.input(current.ID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	project, err := r.projectSvc.CreateFromTemplate(ctx, templateID, in)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "template not found")
+		} else {
+			respondProjectError(w, err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, project)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+)
+
+// === Handlers: Relatórios ===
+
+func (r *Router) handleHoursReport(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	var filter workspace.HoursReportFilter
+	for _, raw := range query["groupBy"] {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				filter.GroupBy = append(filter.GroupBy, workspace.ReportDimension(strings.ToLower(part)))
+			}
+		}
+	}
+	for _, raw := range query["status"] {
+		if raw != "" {
+			filter.Status = append(filter.Status, workspace.TimeEntryStatus(strings.ToLower(raw)))
+		}
+	}
+	if from := query.Get("from"); from != "" {
+		t, err := parseTimeISO(from)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid from date")
+			return
+		}
+		filter.From = &t
+	}
+	if to := query.Get("to"); to != "" {
+		t, err := parseTimeISO(to)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid to date")
+			return
+		}
+		filter.Until = &t
+	}
+	for key, target := range map[string]**uint{
+		"userId":    &filter.UserID,
+		"taskId":    &filter.TaskID,
+		"projectId": &filter.ProjectID,
+	} {
+		if value := query.Get(key); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid "+key)
+				return
+			}
+			v := uint(id)
+			*target = &v
+		}
+	}
+	filter.Client = query.Get("client")
+	filter.Tags = tagsFromQuery(query["tag"])
+
+	ctx, cancel := context.WithTimeout(req.Context(), 10*time.Second)
+	defer cancel()
+
+	// mesmo escopo das listagens: admin vê tudo, demais só os próprios
+	// lançamentos e os dos projetos que possuem
+	if !r.isAdmin(ctx) {
+		current, err := r.currentUser(ctx)
+		if err != nil {
+			respondError(w, http.StatusForbidden, "user not registered in system")
+			return
+		}
+		id := current.ID
+		filter.VisibleTo = &id
+	}
+
+	report, err := r.reportSvc.HoursReport(ctx, filter)
+	if err != nil {
+		respondReportError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, report)
+}
+
+// respondReportError answers 400 for invalid report filters and hides any
+// other failure behind a 500.
+func respondReportError(w http.ResponseWriter, err error) {
+	if errors.Is(err, workspace.ErrInvalidReportFilter) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("report: %v", err)
+	respondError(w, http.StatusInternalServerError, "failed to build report")
+}
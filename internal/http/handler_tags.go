@@ -0,0 +1,249 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Tags ===
+
+func (r *Router) handleCreateTag(w http.ResponseWriter, req *http.Request) {
+	type in struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	tag, err := r.tagSvc.CreateTag(ctx, workspace.TagInput{Name: body.Name, Color: body.Color})
+	if err != nil {
+		respondTagError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, tag)
+}
+
+func (r *Router) handleListTags(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	tags, err := r.tagSvc.ListTags(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list tags")
+		return
+	}
+	respondJSON(w, http.StatusOK, tags)
+}
+
+func (r *Router) handleUpdateTag(w http.ResponseWriter, req *http.Request) {
+	tagID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+	type in struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	tag, err := r.tagSvc.UpdateTag(ctx, tagID, workspace.TagInput{Name: body.Name, Color: body.Color})
+	if err != nil {
+		respondTagError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, tag)
+}
+
+func (r *Router) handleDeleteTag(w http.ResponseWriter, req *http.Request) {
+	tagID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := r.tagSvc.DeleteTag(ctx, tagID); err != nil {
+		respondTagError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleListProjectTags(w http.ResponseWriter, req *http.Request) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canViewProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	tags, err := r.tagSvc.ProjectTags(ctx, projectID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list tags")
+		return
+	}
+	respondJSON(w, http.StatusOK, tags)
+}
+
+func (r *Router) handleTagProject(w http.ResponseWriter, req *http.Request) {
+	r.changeProjectTag(w, req, r.tagSvc.TagProject)
+}
+
+func (r *Router) handleUntagProject(w http.ResponseWriter, req *http.Request) {
+	r.changeProjectTag(w, req, r.tagSvc.UntagProject)
+}
+
+func (r *Router) changeProjectTag(w http.ResponseWriter, req *http.Request, change func(context.Context, uint, uint) error) {
+	projectID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+	tagID, err := parseUintParam(req, "tagID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	project, ok := r.loadProject(ctx, w, projectID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := change(ctx, projectID, tagID); err != nil {
+		respondTagError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleListTaskTags(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	tags, err := r.tagSvc.TaskTags(ctx, taskID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list tags")
+		return
+	}
+	respondJSON(w, http.StatusOK, tags)
+}
+
+func (r *Router) handleTagTask(w http.ResponseWriter, req *http.Request) {
+	r.changeTaskTag(w, req, r.tagSvc.TagTask)
+}
+
+func (r *Router) handleUntagTask(w http.ResponseWriter, req *http.Request) {
+	r.changeTaskTag(w, req, r.tagSvc.UntagTask)
+}
+
+func (r *Router) changeTaskTag(w http.ResponseWriter, req *http.Request, change func(context.Context, uint, uint) error) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+	tagID, err := parseUintParam(req, "tagID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, &task.Project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := change(ctx, taskID, tagID); err != nil {
+		respondTagError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// loadTask fetches a task with its project, writing 404/500 on failure.
+func (r *Router) loadTask(ctx context.Context, w http.ResponseWriter, taskID uint) (*workspace.Task, bool) {
+	task, err := r.taskSvc.GetTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "task not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load task")
+		}
+		return nil, false
+	}
+	return task, true
+}
+
+func respondTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "tag not found")
+	case errors.Is(err, workspace.ErrTagNameTaken), errors.Is(err, workspace.ErrProjectArchived):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
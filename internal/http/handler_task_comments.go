@@ -0,0 +1,222 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Comentários de tarefas ===
+
+func (r *Router) handleCreateTaskComment(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+	type in struct {
+		Body string `json:"body"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	comment, err := r.taskSvc.AddComment(ctx, taskID, current.ID, body.Body)
+	if err != nil {
+		respondCommentError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusCreated, comment)
+}
+
+func (r *Router) handleListTaskComments(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+	page, pageSize := paginationParams(req)
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	result, err := r.taskSvc.ListComments(ctx, workspace.TaskCommentFilter{TaskID: taskID, Page: page, PageSize: pageSize})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list comments")
+		return
+	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) handleUpdateTaskComment(w http.ResponseWriter, req *http.Request) {
+	taskID, commentID, ok := commentParams(w, req)
+	if !ok {
+		return
+	}
+	type in struct {
+		Body string `json:"body"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	comment, current, ok := r.loadComment(ctx, w, task, commentID)
+	if !ok {
+		return
+	}
+	// somente o autor edita o próprio comentário
+	if comment.AuthorID != current.ID {
+		respondError(w, http.StatusForbidden, "only the author can edit a comment")
+		return
+	}
+
+	updated, err := r.taskSvc.UpdateComment(ctx, taskID, commentID, current.ID, body.Body)
+	if err != nil {
+		respondCommentError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+func (r *Router) handleDeleteTaskComment(w http.ResponseWriter, req *http.Request) {
+	taskID, commentID, ok := commentParams(w, req)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	comment, current, ok := r.loadComment(ctx, w, task, commentID)
+	if !ok {
+		return
+	}
+	// o autor ou quem gerencia o projeto pode remover
+	if comment.AuthorID != current.ID && !r.canManageProject(ctx, &task.Project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := r.taskSvc.DeleteComment(ctx, taskID, commentID, current.ID); err != nil {
+		respondCommentError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) handleTaskCommentHistory(w http.ResponseWriter, req *http.Request) {
+	taskID, commentID, ok := commentParams(w, req)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if _, _, ok := r.loadComment(ctx, w, task, commentID); !ok {
+		return
+	}
+
+	revisions, err := r.taskSvc.CommentHistory(ctx, taskID, commentID)
+	if err != nil {
+		respondCommentError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, revisions)
+}
+
+func commentParams(w http.ResponseWriter, req *http.Request) (uint, uint, bool) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return 0, 0, false
+	}
+	commentID, err := parseUintParam(req, "commentID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid comment id")
+		return 0, 0, false
+	}
+	return taskID, commentID, true
+}
+
+// loadComment checks that the caller can see the task and fetches the
+// comment along with the caller, writing the error response on failure.
+func (r *Router) loadComment(ctx context.Context, w http.ResponseWriter, task *workspace.Task, commentID uint) (*workspace.TaskComment, *user.User, bool) {
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return nil, nil, false
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return nil, nil, false
+	}
+	comment, err := r.taskSvc.GetComment(ctx, task.ID, commentID)
+	if err != nil {
+		respondCommentError(w, err)
+		return nil, nil, false
+	}
+	return comment, current, true
+}
+
+func respondCommentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		respondError(w, http.StatusNotFound, "comment not found")
+	case errors.Is(err, workspace.ErrProjectArchived):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
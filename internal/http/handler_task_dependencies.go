@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Dependências entre tarefas ===
+
+func (r *Router) handleListTaskDependencies(w http.ResponseWriter, req *http.Request) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canAccessTask(ctx, task) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	deps, err := r.taskSvc.Dependencies(ctx, taskID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list dependencies")
+		return
+	}
+	respondJSON(w, http.StatusOK, deps)
+}
+
+func (r *Router) handleAddTaskDependency(w http.ResponseWriter, req *http.Request) {
+	r.changeTaskDependency(w, req, r.taskSvc.AddDependency)
+}
+
+func (r *Router) handleRemoveTaskDependency(w http.ResponseWriter, req *http.Request) {
+	r.changeTaskDependency(w, req, r.taskSvc.RemoveDependency)
+}
+
+func (r *Router) changeTaskDependency(w http.ResponseWriter, req *http.Request, change func(context.Context, uint, uint) error) {
+	taskID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid task id")
+		return
+	}
+	predecessorID, err := parseUintParam(req, "predecessorID")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid predecessor id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, ok := r.loadTask(ctx, w, taskID)
+	if !ok {
+		return
+	}
+	if !r.canManageProject(ctx, &task.Project) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	if err := change(ctx, taskID, predecessorID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			respondError(w, http.StatusNotFound, "dependency not found")
+		case errors.Is(err, workspace.ErrProjectArchived):
+			respondError(w, http.StatusConflict, err.Error())
+		default:
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
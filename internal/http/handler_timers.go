@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Cronômetros ===
+
+func (r *Router) handleGetTimer(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	timer, err := r.timerSvc.Current(ctx, current.ID)
+	if err != nil {
+		respondTimerError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, timer)
+}
+
+func (r *Router) handleStartTimer(w http.ResponseWriter, req *http.Request) {
+	type in struct {
+		TaskID uint   `json:"taskId"`
+		Notes  string `json:"notes"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	task, err := r.taskSvc.GetTask(ctx, body.TaskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "task not found")
+		} else {
+			respondError(w, http.StatusInternalServerError, "failed to load task")
+		}
+		return
+	}
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+	if !r.canLogTimeOnTask(ctx, task, current) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	timer, err := r.timerSvc.Start(ctx, workspace.TimerInput{
+		UserID: current.ID,
+		TaskID: body.TaskID,
+		Notes:  body.Notes,
+	})
+	if err != nil {
+		if errors.Is(err, workspace.ErrTimerAlreadyActive) {
+			respondError(w, http.StatusConflict, err.Error())
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, timer)
+}
+
+func (r *Router) handlePauseTimer(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	timer, err := r.timerSvc.Pause(ctx, current.ID)
+	if err != nil {
+		respondTimerError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, timer)
+}
+
+func (r *Router) handleResumeTimer(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	timer, err := r.timerSvc.Resume(ctx, current.ID)
+	if err != nil {
+		respondTimerError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, timer)
+}
+
+func (r *Router) handleStopTimer(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	result, err := r.timerSvc.Stop(ctx, current.ID)
+	if err != nil {
+		respondTimerError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, result)
+}
+
+func (r *Router) handleDiscardTimer(w http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	if err := r.timerSvc.Discard(ctx, current.ID); err != nil {
+		respondTimerError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func respondTimerError(w http.ResponseWriter, err error) {
+	if errors.Is(err, workspace.ErrNoActiveTimer) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	respondTimeEntryError(w, err)
+}
@@ -0,0 +1,257 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/internal/auth"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// === Handlers: Folhas de horas ===
+
+func (r *Router) handleListTimesheets(w http.ResponseWriter, req *http.Request) {
+	page, pageSize := paginationParams(req)
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	filter := workspace.TimesheetFilter{
+		Page:     page,
+		PageSize: pageSize,
+	}
+	for _, raw := range req.URL.Query()["status"] {
+		if raw != "" {
+			filter.Status = append(filter.Status, workspace.TimesheetStatus(strings.ToLower(raw)))
+		}
+	}
+
+	if r.hasAnyRole(ctx, auth.RoleAdmin) {
+		if userIDStr := req.URL.Query().Get("userId"); userIDStr != "" {
+			if userID, err := strconv.ParseUint(userIDStr, 10, 32); err == nil {
+				id := uint(userID)
+				filter.UserID = &id
+			}
+		}
+	} else {
+		current, err := r.currentUser(ctx)
+		if err != nil {
+			respondError(w, http.StatusForbidden, "user not registered in system")
+			return
+		}
+		id := current.ID
+		filter.UserID = &id
+	}
+
+	result, err := r.timesheetSvc.ListTimesheets(ctx, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "unable to list timesheets")
+		return
+	}
+	respondPaginated(w, result.Items, page, pageSize, result.Total)
+}
+
+func (r *Router) handleGetTimesheetWeek(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	year, week, err := workspace.ParseISOWeek(req.PathValue("week"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if !r.isAdminOrOwner(ctx, userID) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	grid, err := r.timesheetSvc.GetWeek(ctx, userID, year, week)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load timesheet")
+		return
+	}
+	respondJSON(w, http.StatusOK, grid)
+}
+
+func (r *Router) handleSaveTimesheetWeek(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	year, week, err := workspace.ParseISOWeek(req.PathValue("week"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	type cell struct {
+		TaskID uint    `json:"taskId"`
+		Date   string  `json:"date"`
+		Hours  float64 `json:"hours"`
+		Notes  string  `json:"notes"`
+	}
+	type in struct {
+		Cells []cell `json:"cells"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if !r.isAdminOrOwner(ctx, userID) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	cells := make([]workspace.TimesheetCellInput, 0, len(body.Cells))
+	checked := map[uint]bool{}
+	for _, c := range body.Cells {
+		date, err := parseTimeISO(c.Date)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid date")
+			return
+		}
+		if !checked[c.TaskID] {
+			task, err := r.taskSvc.GetTask(ctx, c.TaskID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					respondError(w, http.StatusNotFound, "task not found")
+				} else {
+					respondError(w, http.StatusInternalServerError, "failed to load task")
+				}
+				return
+			}
+			if !r.canLogTimeOnTask(ctx, task, current) {
+				respondError(w, http.StatusForbidden, "insufficient permissions")
+				return
+			}
+			checked[c.TaskID] = true
+		}
+		cells = append(cells, workspace.TimesheetCellInput{
+			TaskID: c.TaskID,
+			Date:   date,
+			Hours:  c.Hours,
+			Notes:  c.Notes,
+		})
+	}
+
+	grid, err := r.timesheetSvc.SaveWeek(ctx, userID, year, week, cells)
+	if err != nil {
+		respondTimeEntryError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, grid)
+}
+
+func (r *Router) handleSubmitTimesheet(w http.ResponseWriter, req *http.Request) {
+	userID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	year, week, err := workspace.ParseISOWeek(req.PathValue("week"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	if !r.isAdminOrOwner(ctx, userID) {
+		respondError(w, http.StatusForbidden, "insufficient permissions")
+		return
+	}
+
+	sheet, err := r.timesheetSvc.Submit(ctx, userID, year, week)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, sheet)
+}
+
+func (r *Router) handleApproveTimesheet(w http.ResponseWriter, req *http.Request) {
+	sheetID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid timesheet id")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	sheet, err := r.timesheetSvc.Approve(ctx, sheetID, current.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "timesheet not found")
+		} else {
+			respondTimeEntryError(w, err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, sheet)
+}
+
+func (r *Router) handleRejectTimesheet(w http.ResponseWriter, req *http.Request) {
+	sheetID, err := parseUintParam(req, "id")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid timesheet id")
+		return
+	}
+	type in struct {
+		Reason string `json:"reason"`
+	}
+	var body in
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), 5*time.Second)
+	defer cancel()
+
+	current, err := r.currentUser(ctx)
+	if err != nil {
+		respondError(w, http.StatusForbidden, "user not registered in system")
+		return
+	}
+
+	sheet, err := r.timesheetSvc.Reject(ctx, sheetID, current.ID, body.Reason)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, http.StatusNotFound, "timesheet not found")
+		} else {
+			respondError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, sheet)
+}
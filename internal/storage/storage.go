@@ -0,0 +1,27 @@
+// Package storage keeps binary objects (task attachments) outside the
+// database, either on the local filesystem or in an S3-compatible bucket.
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// ErrNotFound is returned by Get for missing keys. It matches fs.ErrNotExist
+// so callers do not need to import this package to recognize it.
+var ErrNotFound = fmt.Errorf("blob %w", fs.ErrNotExist)
+
+// validateKey accepts slash separated relative keys without empty, "." or
+// ".." segments.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.Contains(segment, `\`) {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+-- Fluxo de revisão dos lançamentos de horas (rejeição, reenvio e reabertura)
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'pending';
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS rejected_at TIMESTAMPTZ;
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS rejected_by INTEGER REFERENCES users(id);
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS reopened_at TIMESTAMPTZ;
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS reopened_by INTEGER REFERENCES users(id);
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS reopen_reason TEXT;
+
+-- Lançamentos já aprovados mantêm o estado aprovado
+UPDATE time_entries SET status = 'approved' WHERE approved_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_status ON time_entries (status);
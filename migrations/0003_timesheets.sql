@@ -0,0 +1,19 @@
+-- Folhas de horas semanais (usuário + semana ISO)
+CREATE TABLE IF NOT EXISTS timesheets (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id),
+  year INTEGER NOT NULL,
+  week INTEGER NOT NULL,
+  status TEXT NOT NULL DEFAULT 'draft',
+  submitted_at TIMESTAMPTZ,
+  approved_at TIMESTAMPTZ,
+  approved_by INTEGER REFERENCES users(id),
+  rejected_at TIMESTAMPTZ,
+  rejected_by INTEGER REFERENCES users(id),
+  rejection_reason TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timesheets_user_week ON timesheets (user_id, year, week);
+CREATE INDEX IF NOT EXISTS idx_timesheets_status ON timesheets (status);
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_date ON time_entries (user_id, entry_date);
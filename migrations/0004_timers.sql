@@ -0,0 +1,20 @@
+-- Cronômetros de trabalho convertidos em lançamentos de horas ao parar
+CREATE TABLE IF NOT EXISTS timers (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES users(id),
+  task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'running',
+  notes TEXT,
+  started_at TIMESTAMPTZ NOT NULL,
+  last_resumed_at TIMESTAMPTZ,
+  elapsed_seconds BIGINT NOT NULL DEFAULT 0,
+  stopped_at TIMESTAMPTZ,
+  auto_stopped BOOLEAN NOT NULL DEFAULT FALSE,
+  time_entry_id INTEGER REFERENCES time_entries(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_timers_user_id ON timers (user_id);
+-- Apenas um cronômetro ativo (em execução ou pausado) por usuário
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timers_one_active_per_user
+  ON timers (user_id) WHERE status IN ('running', 'paused');
@@ -0,0 +1,15 @@
+-- Avisos da política de horas registrados no lançamento (JSON com lista de mensagens)
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS warnings TEXT;
+
+-- Políticas de horas por usuário (sobrepõem a política padrão configurada)
+CREATE TABLE IF NOT EXISTS hour_policies (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+  max_daily_hours NUMERIC(5,2) NOT NULL DEFAULT 0,
+  max_weekly_hours NUMERIC(6,2) NOT NULL DEFAULT 0,
+  daily_overtime_hours NUMERIC(5,2) NOT NULL DEFAULT 0,
+  weekly_overtime_hours NUMERIC(6,2) NOT NULL DEFAULT 0,
+  weekend_rule TEXT NOT NULL DEFAULT 'allow',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
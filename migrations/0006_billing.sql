@@ -0,0 +1,56 @@
+-- Taxas horárias por projeto, por usuário ou por projeto+usuário, com vigência
+CREATE TABLE IF NOT EXISTS billing_rates (
+  id SERIAL PRIMARY KEY,
+  project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+  user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+  hourly_rate NUMERIC(10,2) NOT NULL,
+  currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+  effective_from TIMESTAMPTZ NOT NULL,
+  effective_to TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (project_id IS NOT NULL OR user_id IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_billing_rates_project_id ON billing_rates (project_id);
+CREATE INDEX IF NOT EXISTS idx_billing_rates_user_id ON billing_rates (user_id);
+
+-- Faturas: fotografia dos lançamentos aprovados de um projeto no período
+CREATE TABLE IF NOT EXISTS invoices (
+  id SERIAL PRIMARY KEY,
+  number VARCHAR(30) UNIQUE,
+  project_id INTEGER NOT NULL REFERENCES projects(id),
+  client_name VARCHAR(120) NOT NULL,
+  period_start TIMESTAMPTZ NOT NULL,
+  period_end TIMESTAMPTZ NOT NULL,
+  status TEXT NOT NULL DEFAULT 'draft',
+  currency VARCHAR(3) NOT NULL,
+  total_hours NUMERIC(10,2) NOT NULL,
+  total_amount NUMERIC(12,2) NOT NULL,
+  created_by INTEGER NOT NULL REFERENCES users(id),
+  issued_at TIMESTAMPTZ,
+  paid_at TIMESTAMPTZ,
+  voided_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_invoices_project_id ON invoices (project_id);
+
+-- Linhas da fatura (mantidas mesmo quando a fatura é anulada)
+CREATE TABLE IF NOT EXISTS invoice_lines (
+  id SERIAL PRIMARY KEY,
+  invoice_id INTEGER NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+  time_entry_id INTEGER NOT NULL REFERENCES time_entries(id),
+  task_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  entry_date TIMESTAMPTZ NOT NULL,
+  description VARCHAR(255),
+  hours NUMERIC(5,2) NOT NULL,
+  rate NUMERIC(10,2) NOT NULL,
+  amount NUMERIC(12,2) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines (invoice_id);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_time_entry_id ON invoice_lines (time_entry_id);
+
+-- Lançamento faturado fica travado para edição e nova fatura
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS invoice_id INTEGER REFERENCES invoices(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_time_entries_invoice_id ON time_entries (invoice_id);
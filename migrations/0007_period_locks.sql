@@ -0,0 +1,19 @@
+-- Fechamento contábil: períodos travados (global ou por projeto).
+-- Reaberturas não apagam a linha, que serve de histórico de quem fechou/reabriu.
+CREATE TABLE IF NOT EXISTS period_locks (
+  id SERIAL PRIMARY KEY,
+  project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+  period_start TIMESTAMPTZ NOT NULL,
+  period_end TIMESTAMPTZ NOT NULL,
+  reason VARCHAR(255),
+  closed_by INTEGER NOT NULL REFERENCES users(id),
+  closed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  reopened_by INTEGER REFERENCES users(id),
+  reopened_at TIMESTAMPTZ,
+  reopen_reason VARCHAR(255),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (period_end >= period_start)
+);
+CREATE INDEX IF NOT EXISTS idx_period_locks_project_id ON period_locks (project_id);
+CREATE INDEX IF NOT EXISTS idx_period_locks_reopened_at ON period_locks (reopened_at);
@@ -0,0 +1,18 @@
+-- Substitutos que aprovam horas no lugar do dono do projeto durante ausências
+CREATE TABLE IF NOT EXISTS approval_delegations (
+  id SERIAL PRIMARY KEY,
+  delegator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  delegate_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  project_id INTEGER REFERENCES projects(id) ON DELETE CASCADE,
+  starts_on TIMESTAMPTZ NOT NULL,
+  ends_on TIMESTAMPTZ NOT NULL,
+  reason VARCHAR(255),
+  created_by INTEGER NOT NULL REFERENCES users(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  CHECK (delegator_id <> delegate_id),
+  CHECK (ends_on >= starts_on)
+);
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_delegator_id ON approval_delegations (delegator_id);
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_delegate_id ON approval_delegations (delegate_id);
+CREATE INDEX IF NOT EXISTS idx_approval_delegations_project_id ON approval_delegations (project_id);
@@ -0,0 +1,10 @@
+-- Orçamento de horas (e opcionalmente de valor) por projeto e estimativa por tarefa
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_hours NUMERIC(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_amount NUMERIC(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3) NOT NULL DEFAULT 'BRL';
+-- Percentuais do orçamento que geram alerta, em JSON (ex.: [80, 100])
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS budget_thresholds TEXT;
+-- Orçamento rígido bloqueia lançamentos que ultrapassem as horas orçadas
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS hard_budget BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_hours NUMERIC(7,2) NOT NULL DEFAULT 0;
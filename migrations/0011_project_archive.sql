@@ -0,0 +1,6 @@
+-- Projetos arquivados ficam somente leitura (projeto, tarefas e lançamentos)
+-- e deixam de aparecer nas listagens, preservando o histórico de horas aprovadas
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS archived_by INTEGER REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_projects_archived_at ON projects (archived_at);
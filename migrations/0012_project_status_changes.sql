@@ -0,0 +1,17 @@
+-- Histórico de mudanças de status dos projetos (o status inicial tem from_status vazio)
+CREATE TABLE IF NOT EXISTS project_status_changes (
+  id SERIAL PRIMARY KEY,
+  project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  from_status TEXT NOT NULL DEFAULT '',
+  to_status TEXT NOT NULL,
+  reason TEXT,
+  changed_by INTEGER NOT NULL REFERENCES users(id),
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_project_status_changes_project_id ON project_status_changes (project_id);
+
+-- Projetos existentes ganham o status atual como primeiro registro
+INSERT INTO project_status_changes (project_id, to_status, changed_by, changed_at)
+SELECT p.id, p.status, p.owner_id, p.created_at
+FROM projects p
+WHERE NOT EXISTS (SELECT 1 FROM project_status_changes c WHERE c.project_id = p.id);
@@ -0,0 +1,43 @@
+-- Clientes como entidade própria; projects.client_name segue como cópia do nome
+CREATE TABLE IF NOT EXISTS clients (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(120) NOT NULL,
+  legal_name VARCHAR(200),
+  tax_id VARCHAR(30),
+  billing_email VARCHAR(160),
+  billing_address VARCHAR(300),
+  currency VARCHAR(3) NOT NULL DEFAULT 'BRL',
+  notes VARCHAR(500),
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_clients_name ON clients (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS client_contacts (
+  id SERIAL PRIMARY KEY,
+  client_id INTEGER NOT NULL REFERENCES clients(id) ON DELETE CASCADE,
+  name VARCHAR(120) NOT NULL,
+  email VARCHAR(160),
+  phone VARCHAR(40),
+  role VARCHAR(80),
+  is_primary BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX IF NOT EXISTS idx_client_contacts_client_id ON client_contacts (client_id);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS client_id INTEGER REFERENCES clients(id);
+CREATE INDEX IF NOT EXISTS idx_projects_client_id ON projects (client_id);
+
+-- Um cliente por nome distinto (sem diferenciar maiúsculas) já usado nos projetos
+INSERT INTO clients (name)
+SELECT MIN(TRIM(client_name))
+FROM projects
+WHERE TRIM(client_name) <> ''
+GROUP BY LOWER(TRIM(client_name))
+ON CONFLICT DO NOTHING;
+
+UPDATE projects
+SET client_id = c.id, client_name = c.name
+FROM clients c
+WHERE projects.client_id IS NULL
+  AND LOWER(c.name) = LOWER(TRIM(projects.client_name));
@@ -0,0 +1,16 @@
+-- Marcos de entrega dentro dos projetos
+CREATE TABLE IF NOT EXISTS milestones (
+  id SERIAL PRIMARY KEY,
+  project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  name VARCHAR(120) NOT NULL,
+  description VARCHAR(500),
+  target_date TIMESTAMPTZ NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'planned',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_milestones_project_id ON milestones (project_id);
+
+-- Tarefas podem pertencer a um marco; remover o marco só desvincula as tarefas
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id INTEGER REFERENCES milestones(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_milestone_id ON tasks (milestone_id);
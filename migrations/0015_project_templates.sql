@@ -0,0 +1,30 @@
+-- Modelos de projeto: marcos e tarefas com datas em dias a partir do início do projeto
+CREATE TABLE IF NOT EXISTS project_templates (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(120) NOT NULL,
+  description VARCHAR(500),
+  created_by INTEGER NOT NULL REFERENCES users(id),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS project_template_milestones (
+  id SERIAL PRIMARY KEY,
+  template_id INTEGER NOT NULL REFERENCES project_templates(id) ON DELETE CASCADE,
+  name VARCHAR(120) NOT NULL,
+  description VARCHAR(500),
+  target_offset_days INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_project_template_milestones_template_id ON project_template_milestones (template_id);
+
+CREATE TABLE IF NOT EXISTS project_template_tasks (
+  id SERIAL PRIMARY KEY,
+  template_id INTEGER NOT NULL REFERENCES project_templates(id) ON DELETE CASCADE,
+  title VARCHAR(150) NOT NULL,
+  description VARCHAR(500),
+  assignee_id INTEGER NOT NULL REFERENCES users(id),
+  due_offset_days INTEGER,
+  estimate_hours NUMERIC(7,2) NOT NULL DEFAULT 0,
+  milestone_id INTEGER REFERENCES project_template_milestones(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_project_template_tasks_template_id ON project_template_tasks (template_id);
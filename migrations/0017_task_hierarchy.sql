@@ -0,0 +1,6 @@
+-- Subtarefas: tarefa pai opcional, com profundidade livre dentro do mesmo projeto
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+
+-- Modelos de projeto preservam a hierarquia das tarefas
+ALTER TABLE project_template_tasks ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES project_template_tasks(id) ON DELETE SET NULL;
@@ -0,0 +1,9 @@
+-- Dependências término-início entre tarefas do mesmo projeto
+CREATE TABLE IF NOT EXISTS task_dependencies (
+  task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  predecessor_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (task_id, predecessor_id),
+  CHECK (task_id <> predecessor_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_predecessor_id ON task_dependencies (predecessor_id);
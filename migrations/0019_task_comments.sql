@@ -0,0 +1,25 @@
+-- Comentários de tarefas com histórico de edição e exclusão lógica
+CREATE TABLE IF NOT EXISTS task_comments (
+  id SERIAL PRIMARY KEY,
+  task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  author_id INTEGER NOT NULL,
+  body VARCHAR(2000) NOT NULL,
+  edited_at TIMESTAMPTZ,
+  deleted_by INTEGER,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id ON task_comments (task_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_author_id ON task_comments (author_id);
+CREATE INDEX IF NOT EXISTS idx_task_comments_deleted_at ON task_comments (deleted_at);
+
+-- Versões anteriores do texto de cada comentário
+CREATE TABLE IF NOT EXISTS task_comment_revisions (
+  id SERIAL PRIMARY KEY,
+  comment_id INTEGER NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+  body VARCHAR(2000) NOT NULL,
+  edited_by INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_task_comment_revisions_comment_id ON task_comment_revisions (comment_id);
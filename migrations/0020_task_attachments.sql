@@ -0,0 +1,15 @@
+-- Anexos de tarefas: metadados e checksum; o conteúdo fica no armazenamento (local ou S3).
+-- A FK não usa CASCADE: excluir o projeto é recusado enquanto houver anexos
+-- (ErrProjectHasAttachments), senão os arquivos ficariam órfãos no armazenamento.
+CREATE TABLE IF NOT EXISTS task_attachments (
+  id SERIAL PRIMARY KEY,
+  task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE RESTRICT,
+  file_name VARCHAR(255) NOT NULL,
+  content_type VARCHAR(120) NOT NULL,
+  size_bytes BIGINT NOT NULL,
+  checksum VARCHAR(64) NOT NULL,
+  storage_key VARCHAR(300) NOT NULL UNIQUE,
+  uploaded_by INTEGER NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_task_attachments_task_id ON task_attachments (task_id);
@@ -0,0 +1,2 @@
+-- Estimativa restante das tarefas; NULL deriva o restante da estimativa original menos as horas lançadas
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS remaining_estimate_hours NUMERIC(7,2);
@@ -0,0 +1,280 @@
+package timeimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// errDryRun rolls back the transaction of a preview.
+var errDryRun = errors.New("dry run")
+
+// Options controls an import run.
+type Options struct {
+	Source Source
+	DryRun bool
+}
+
+// RowResult reports what happened to one line of the file.
+type RowResult struct {
+	Line      int
+	Project   string
+	Task      string
+	User      string
+	Date      string
+	Hours     float64
+	ProjectID *uint
+	TaskID    *uint
+	UserID    *uint
+	EntryID   *uint
+	Error     string
+}
+
+// Report summarizes an import run.
+type Report struct {
+	Source   Source
+	DryRun   bool
+	Total    int
+	Valid    int
+	Invalid  int
+	Imported int
+	Rows     []RowResult
+}
+
+// Service maps parsed rows onto projects, tasks and users and logs them
+// through the time entry service so every business rule applies.
+type Service struct {
+	db      *gorm.DB
+	timeSvc *workspace.TimeEntryService
+}
+
+func NewService(db *gorm.DB, timeSvc *workspace.TimeEntryService) *Service {
+	return &Service{db: db, timeSvc: timeSvc}
+}
+
+// Import parses the file and logs every valid row in a single transaction.
+// Invalid rows are reported and skipped; in dry-run mode nothing is kept.
+func (s *Service) Import(ctx context.Context, r io.Reader, opts Options) (*Report, error) {
+	rows, err := Parse(opts.Source, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Source: opts.Source, DryRun: opts.DryRun, Total: len(rows)}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		resolver := newResolver(tx)
+		timeSvc := s.timeSvc.WithTx(tx)
+		for _, row := range rows {
+			result := s.importRow(ctx, tx, timeSvc, resolver, row)
+			if result.Error == "" {
+				report.Valid++
+			} else {
+				report.Invalid++
+			}
+			report.Rows = append(report.Rows, result)
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	if !opts.DryRun {
+		report.Imported = report.Valid
+	}
+	return report, nil
+}
+
+func (s *Service) importRow(ctx context.Context, tx *gorm.DB, timeSvc *workspace.TimeEntryService, resolver *resolver, row Row) RowResult {
+	result := RowResult{
+		Line:    row.Line,
+		Project: row.Project,
+		Task:    row.Task,
+		User:    firstNonEmpty(row.UserEmail, row.UserName),
+		Hours:   row.Hours,
+		Error:   row.Error,
+	}
+	if !row.Date.IsZero() {
+		result.Date = row.Date.Format("2006-01-02")
+	}
+	if result.Error != "" {
+		return result
+	}
+
+	project, err := resolver.project(ctx, row.Project)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.ProjectID = &project.ID
+	task, err := resolver.task(ctx, project.ID, row.Task)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.TaskID = &task.ID
+	u, err := resolver.user(ctx, row.UserEmail, row.UserName)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.UserID = &u.ID
+
+	// each row runs in a savepoint so a failing insert does not abort the batch
+	err = tx.Transaction(func(sp *gorm.DB) error {
+		entry, err := timeSvc.WithTx(sp).LogTime(ctx, workspace.TimeEntryInput{
+			TaskID:    task.ID,
+			UserID:    u.ID,
+			EntryDate: row.Date,
+			Hours:     row.Hours,
+			Notes:     truncate(row.Notes, 255),
+		})
+		if err != nil {
+			return err
+		}
+		result.EntryID = &entry.ID
+		return nil
+	})
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// resolver caches name lookups for the duration of an import.
+type resolver struct {
+	db       *gorm.DB
+	projects map[string]*workspace.Project
+	tasks    map[string]*workspace.Task
+	users    map[string]*user.User
+}
+
+func newResolver(db *gorm.DB) *resolver {
+	return &resolver{
+		db:       db,
+		projects: map[string]*workspace.Project{},
+		tasks:    map[string]*workspace.Task{},
+		users:    map[string]*user.User{},
+	}
+}
+
+func (r *resolver) project(ctx context.Context, name string) (*workspace.Project, error) {
+	key := normalize(name)
+	if key == "" {
+		return nil, errors.New("project is required")
+	}
+	if p, ok := r.projects[key]; ok {
+		return p, nil
+	}
+	var matches []workspace.Project
+	if err := r.db.WithContext(ctx).Where("LOWER(name) = ?", key).Limit(2).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("project %q not found", name)
+	case 1:
+		r.projects[key] = &matches[0]
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("project name %q is ambiguous", name)
+	}
+}
+
+func (r *resolver) task(ctx context.Context, projectID uint, title string) (*workspace.Task, error) {
+	key := normalize(title)
+	if key == "" {
+		return nil, errors.New("task is required")
+	}
+	cacheKey := fmt.Sprintf("%d|%s", projectID, key)
+	if t, ok := r.tasks[cacheKey]; ok {
+		return t, nil
+	}
+	var matches []workspace.Task
+	if err := r.db.WithContext(ctx).
+		Where("project_id = ? AND LOWER(title) = ?", projectID, key).
+		Limit(2).
+		Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("task %q not found in project", title)
+	case 1:
+		r.tasks[cacheKey] = &matches[0]
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("task title %q is ambiguous", title)
+	}
+}
+
+// user matches by e-mail first and falls back to the display name.
+func (r *resolver) user(ctx context.Context, email, name string) (*user.User, error) {
+	if key := normalize(email); key != "" {
+		if u, ok := r.users["email|"+key]; ok {
+			return u, nil
+		}
+		var u user.User
+		err := r.db.WithContext(ctx).Where("LOWER(email) = ?", key).First(&u).Error
+		if err == nil {
+			r.users["email|"+key] = &u
+			return &u, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	key := normalize(name)
+	if key == "" {
+		if email != "" {
+			return nil, fmt.Errorf("user %q not found", email)
+		}
+		return nil, errors.New("user is required")
+	}
+	if u, ok := r.users["name|"+key]; ok {
+		return u, nil
+	}
+	var matches []user.User
+	if err := r.db.WithContext(ctx).Where("LOWER(name) = ?", key).Limit(2).Find(&matches).Error; err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("user %q not found", firstNonEmpty(email, name))
+	case 1:
+		r.users["name|"+key] = &matches[0]
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("user name %q is ambiguous", name)
+	}
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max])
+}
@@ -0,0 +1,104 @@
+package timeimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/user"
+	"github.com/v-Kaefer/Const-Software-25-02/pkg/workspace"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newImportTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:timeimport_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&user.User{}, &workspace.Project{}, &workspace.Task{}, &workspace.TimeEntry{}, &workspace.Timesheet{}, &workspace.HourPolicy{}, &workspace.PeriodLock{}, &workspace.ProjectMember{}, &workspace.ProjectStatusChange{}, &workspace.Client{}, &workspace.ClientContact{}, &workspace.Milestone{}, &workspace.ProjectTemplate{}, &workspace.ProjectTemplateMilestone{}, &workspace.ProjectTemplateTask{}, &workspace.Tag{}, &workspace.ProjectTag{}, &workspace.TaskTag{}, &workspace.TaskDependency{}, &workspace.TaskComment{}, &workspace.TaskCommentRevision{}, &workspace.TaskAttachment{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func seedImportFixtures(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	ctx := context.Background()
+	if err := db.Create(&user.User{Email: "ana@example.com", Name: "Ana Souza"}).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := workspace.NewClientService(db).CreateClient(ctx, workspace.ClientInput{Name: "ACME"}); err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	project, err := workspace.NewProjectService(db).CreateProject(ctx, workspace.ProjectInput{
+		Name:       "Portal",
+		ClientName: "ACME",
+		StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if _, err := workspace.NewTaskService(db).CreateTask(ctx, workspace.TaskInput{ProjectID: project.ID, Title: "Backend", AssigneeID: 1}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+}
+
+const harvestFixture = "Date,Project,Task,Notes,Hours,First Name,Last Name\n" +
+	"2024-03-04,portal,BACKEND,ok,2,Ana,Souza\n" +
+	"2024-03-05,Unknown,Backend,,1,Ana,Souza\n" +
+	"2024-03-06,Portal,Backend,,0,Ana,Souza\n" +
+	"2024-03-07,Portal,Backend,,1,Bruno,Lima\n"
+
+func TestImport_DryRunDoesNotPersist(t *testing.T) {
+	db := newImportTestDB(t)
+	seedImportFixtures(t, db)
+	svc := NewService(db, workspace.NewTimeEntryService(db))
+
+	report, err := svc.Import(context.Background(), strings.NewReader(harvestFixture), Options{Source: SourceHarvest, DryRun: true})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if report.Total != 4 || report.Valid != 1 || report.Invalid != 3 || report.Imported != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, row := range report.Rows[1:] {
+		if row.Error == "" {
+			t.Fatalf("expected error on line %d", row.Line)
+		}
+	}
+	if !strings.Contains(report.Rows[1].Error, "project") || !strings.Contains(report.Rows[3].Error, "user") {
+		t.Fatalf("unexpected row errors: %+v", report.Rows)
+	}
+
+	var count int64
+	db.Model(&workspace.TimeEntry{}).Count(&count)
+	if count != 0 {
+		t.Fatalf("dry run must not persist entries, found %d", count)
+	}
+}
+
+func TestImport_CommitsValidRows(t *testing.T) {
+	db := newImportTestDB(t)
+	seedImportFixtures(t, db)
+	svc := NewService(db, workspace.NewTimeEntryService(db))
+
+	report, err := svc.Import(context.Background(), strings.NewReader(harvestFixture), Options{Source: SourceHarvest})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if report.Imported != 1 || report.Rows[0].EntryID == nil {
+		t.Fatalf("expected one imported entry, got %+v", report)
+	}
+
+	var entries []workspace.TimeEntry
+	db.Find(&entries)
+	if len(entries) != 1 || entries[0].Hours != 2 || entries[0].Status != workspace.TimeEntryPending {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+}
@@ -0,0 +1,297 @@
+// Package timeimport loads time entries exported from other trackers
+// (Toggl, Harvest, Clockify) into the workspace.
+package timeimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidFile reports a file that cannot be imported at all: empty, with
+// an unreadable header or without the columns the source needs.
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Source identifies the tracker that produced the CSV.
+type Source string
+
+const (
+	SourceToggl    Source = "toggl"
+	SourceHarvest  Source = "harvest"
+	SourceClockify Source = "clockify"
+)
+
+// ParseSource validates a source name.
+func ParseSource(value string) (Source, error) {
+	switch s := Source(strings.ToLower(strings.TrimSpace(value))); s {
+	case SourceToggl, SourceHarvest, SourceClockify:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unsupported import source %q", value)
+	}
+}
+
+// Row is one parsed line of an export. Error is set when the line could not
+// be parsed; the remaining fields are then best effort.
+type Row struct {
+	Line      int
+	Project   string
+	Task      string
+	UserEmail string
+	UserName  string
+	Date      time.Time
+	Hours     float64
+	Notes     string
+	Error     string
+}
+
+// Parse reads a CSV export of the given source.
+func Parse(source Source, r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, fmt.Errorf("%w: file is empty", ErrInvalidFile)
+		case errors.As(err, &parseErr):
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		return nil, err
+	}
+	cols := headerIndex(header)
+
+	var parseRow func(get func(string) string) (Row, error)
+	switch source {
+	case SourceToggl:
+		if err := cols.require("project", "start date", "duration"); err != nil {
+			return nil, err
+		}
+		parseRow = parseTogglRow
+	case SourceHarvest:
+		if err := cols.require("date", "project", "hours", "first name", "last name"); err != nil {
+			return nil, err
+		}
+		parseRow = parseHarvestRow
+	case SourceClockify:
+		if err := cols.require("project", "start date", "duration (decimal)"); err != nil {
+			return nil, err
+		}
+		parseRow = parseClockifyRow
+	default:
+		return nil, fmt.Errorf("%w: unsupported import source %q", ErrInvalidFile, source)
+	}
+
+	var rows []Row
+	line := 1
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			// malformed lines are reported, read failures abort the import
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, Row{Line: line, Error: err.Error()})
+			continue
+		}
+		if isBlank(record) {
+			continue
+		}
+		get := func(name string) string { return cols.value(record, name) }
+		row, err := parseRow(get)
+		row.Line = line
+		if err != nil {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Toggl "Detailed report" export.
+func parseTogglRow(get func(string) string) (Row, error) {
+	row := Row{
+		Project:   get("project"),
+		Task:      get("task"),
+		UserEmail: get("email"),
+		UserName:  get("user"),
+		Notes:     get("description"),
+	}
+	date, err := parseDate(get("start date"), get("start time"))
+	if err != nil {
+		return row, err
+	}
+	row.Date = date
+	hours, err := parseClockDuration(get("duration"))
+	if err != nil {
+		return row, err
+	}
+	row.Hours = hours
+	return row, nil
+}
+
+// Harvest "Detailed time" export; it carries names but no e-mail.
+func parseHarvestRow(get func(string) string) (Row, error) {
+	row := Row{
+		Project:  get("project"),
+		Task:     get("task"),
+		UserName: strings.TrimSpace(get("first name") + " " + get("last name")),
+		Notes:    get("notes"),
+	}
+	date, err := parseDate(get("date"), "")
+	if err != nil {
+		return row, err
+	}
+	row.Date = date
+	hours, err := parseDecimalHours(get("hours"))
+	if err != nil {
+		return row, err
+	}
+	row.Hours = hours
+	return row, nil
+}
+
+// Clockify "Detailed report" export.
+func parseClockifyRow(get func(string) string) (Row, error) {
+	row := Row{
+		Project:   get("project"),
+		Task:      get("task"),
+		UserEmail: get("email"),
+		UserName:  get("user"),
+		Notes:     get("description"),
+	}
+	date, err := parseDate(get("start date"), get("start time"))
+	if err != nil {
+		return row, err
+	}
+	row.Date = date
+	hours, err := parseDecimalHours(get("duration (decimal)"))
+	if err != nil {
+		return row, err
+	}
+	row.Hours = hours
+	return row, nil
+}
+
+var dateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+
+var timeLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "03:04 PM"}
+
+// parseDate accepts ISO, US (MM/DD/YYYY) and dotted European dates, with an
+// optional time of day. Values are interpreted as UTC.
+func parseDate(date, clock string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return time.Time{}, errors.New("date is required")
+	}
+	var day time.Time
+	var err error
+	for _, layout := range dateLayouts {
+		if day, err = time.Parse(layout, date); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", date)
+	}
+	clock = strings.TrimSpace(clock)
+	if clock == "" {
+		return day, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, strings.ToUpper(clock)); err == nil {
+			return day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", clock)
+}
+
+// parseClockDuration parses "HH:MM:SS" (or "HH:MM") into decimal hours.
+func parseClockDuration(value string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	var total float64
+	factors := []float64{1, 1.0 / 60, 1.0 / 3600}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		total += float64(n) * factors[i]
+	}
+	return roundHours(total), nil
+}
+
+// parseDecimalHours accepts "1.5" and "1,5".
+func parseDecimalHours(value string) (float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	if strings.Contains(value, ":") {
+		return parseClockDuration(value)
+	}
+	hours, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hours %q", value)
+	}
+	return roundHours(hours), nil
+}
+
+func roundHours(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+type columns map[string]int
+
+func headerIndex(header []string) columns {
+	cols := make(columns, len(header))
+	for i, h := range header {
+		// spreadsheet tools often prepend a BOM to the first header
+		h = strings.TrimPrefix(h, "\ufeff")
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	return cols
+}
+
+func (c columns) require(names ...string) error {
+	var missing []string
+	for _, n := range names {
+		if _, ok := c[n]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: missing columns: %s", ErrInvalidFile, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (c columns) value(record []string, name string) string {
+	i, ok := c[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
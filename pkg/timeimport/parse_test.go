@@ -0,0 +1,85 @@
+package timeimport
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_Toggl(t *testing.T) {
+	csv := "\ufeffUser,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration\n" +
+		"Ana Souza,ana@example.com,ACME,Portal,Backend,API review,Yes,2024-03-04,09:00:00,2024-03-04,10:30:00,01:30:00\n" +
+		"Ana Souza,ana@example.com,ACME,Portal,Backend,,Yes,2024-03-05,09:00:00,2024-03-05,09:00:00,bad\n"
+
+	rows, err := Parse(SourceToggl, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0]
+	if first.Error != "" || first.Hours != 1.5 || first.UserEmail != "ana@example.com" || first.Task != "Backend" {
+		t.Fatalf("unexpected first row: %+v", first)
+	}
+	if want := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC); !first.Date.Equal(want) {
+		t.Fatalf("expected date %s, got %s", want, first.Date)
+	}
+	if rows[1].Line != 3 || rows[1].Error == "" {
+		t.Fatalf("expected error on line 3, got %+v", rows[1])
+	}
+}
+
+func TestParse_Harvest(t *testing.T) {
+	csv := "Date,Client,Project,Project Code,Task,Notes,Hours,Billable?,First Name,Last Name\n" +
+		"03/04/2024,ACME,Portal,P1,Backend,Deploy,\"2,25\",Yes,Ana,Souza\n"
+
+	rows, err := Parse(SourceHarvest, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	row := rows[0]
+	if row.Error != "" || row.Hours != 2.25 || row.UserName != "Ana Souza" || row.UserEmail != "" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if want := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC); !row.Date.Equal(want) {
+		t.Fatalf("expected date %s, got %s", want, row.Date)
+	}
+}
+
+func TestParse_Clockify(t *testing.T) {
+	csv := "Project,Client,Description,Task,User,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)\n" +
+		"Portal,ACME,Meeting,Backend,Ana Souza,ana@example.com,,Yes,04.03.2024,02:00 PM,04.03.2024,02:45 PM,00:45:00,0.75\n\n"
+
+	rows, err := Parse(SourceClockify, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected blank lines to be skipped, got %d rows", len(rows))
+	}
+	row := rows[0]
+	if row.Error != "" || row.Hours != 0.75 || row.Notes != "Meeting" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if want := time.Date(2024, 3, 4, 14, 0, 0, 0, time.UTC); !row.Date.Equal(want) {
+		t.Fatalf("expected date %s, got %s", want, row.Date)
+	}
+}
+
+func TestParse_MissingColumns(t *testing.T) {
+	_, err := Parse(SourceToggl, strings.NewReader("Project,Description\nPortal,x\n"))
+	if !errors.Is(err, ErrInvalidFile) || !strings.Contains(err.Error(), "start date") {
+		t.Fatalf("expected missing column error, got %v", err)
+	}
+	if _, err := Parse(SourceToggl, strings.NewReader("")); !errors.Is(err, ErrInvalidFile) {
+		t.Fatalf("expected an empty file to be invalid, got %v", err)
+	}
+	if _, err := ParseSource("jira"); err == nil {
+		t.Fatalf("expected unsupported source error")
+	}
+}
@@ -0,0 +1,189 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DelegationInput names a substitute for an absent project owner.
+type DelegationInput struct {
+	DelegatorID uint
+	DelegateID  uint
+	ProjectID   *uint
+	StartsOn    time.Time
+	EndsOn      time.Time
+	Reason      string
+	CreatedBy   uint
+}
+
+// DelegationFilter holds filters for delegation listings.
+type DelegationFilter struct {
+	DelegatorID *uint
+	DelegateID  *uint
+	ActiveOn    *time.Time
+}
+
+// ReviewScope limits a non-admin reviewer to the entries of some projects.
+// Reviewers never review their own entries.
+type ReviewScope struct {
+	ReviewerID uint
+	ProjectIDs []uint
+}
+
+// Allows reports whether an entry of the user on the project may be reviewed.
+func (sc *ReviewScope) Allows(projectID, userID uint) bool {
+	if userID == sc.ReviewerID {
+		return false
+	}
+	for _, id := range sc.ProjectIDs {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply narrows a time entry filter to the scope.
+func (sc *ReviewScope) Apply(filter TimeEntryFilter) TimeEntryFilter {
+	filter.ProjectIDs = sc.ProjectIDs
+	if len(filter.ProjectIDs) == 0 {
+		// nenhum projeto: a consulta não deve retornar nada
+		filter.ProjectIDs = []uint{0}
+	}
+	reviewer := sc.ReviewerID
+	filter.ExcludeUserID = &reviewer
+	return filter
+}
+
+// DelegationService manages approval delegations and resolves what a
+// non-admin reviewer is allowed to approve.
+type DelegationService struct {
+	db *gorm.DB
+}
+
+func NewDelegationService(db *gorm.DB) *DelegationService {
+	return &DelegationService{db: db}
+}
+
+func (s *DelegationService) CreateDelegation(ctx context.Context, in DelegationInput) (*ApprovalDelegation, error) {
+	if in.DelegatorID == 0 || in.DelegateID == 0 {
+		return nil, errors.New("delegator and delegate are required")
+	}
+	if in.DelegatorID == in.DelegateID {
+		return nil, errors.New("delegate must differ from delegator")
+	}
+	if in.CreatedBy == 0 {
+		return nil, errors.New("creator is required")
+	}
+	if in.StartsOn.IsZero() || in.EndsOn.IsZero() {
+		return nil, errors.New("start and end dates are required")
+	}
+	startsOn, endsOn := truncateDay(in.StartsOn), truncateDay(in.EndsOn)
+	if endsOn.Before(startsOn) {
+		return nil, errors.New("end date cannot be before start date")
+	}
+	if in.ProjectID != nil {
+		var project Project
+		if err := s.db.WithContext(ctx).First(&project, *in.ProjectID).Error; err != nil {
+			return nil, err
+		}
+		if project.OwnerID != in.DelegatorID {
+			return nil, errors.New("delegator does not own the project")
+		}
+	}
+
+	delegation := &ApprovalDelegation{
+		DelegatorID: in.DelegatorID,
+		DelegateID:  in.DelegateID,
+		ProjectID:   in.ProjectID,
+		StartsOn:    startsOn,
+		EndsOn:      endsOn,
+		Reason:      strings.TrimSpace(in.Reason),
+		CreatedBy:   in.CreatedBy,
+	}
+	if err := s.db.WithContext(ctx).Create(delegation).Error; err != nil {
+		return nil, err
+	}
+	return delegation, nil
+}
+
+func (s *DelegationService) ListDelegations(ctx context.Context, filter DelegationFilter) ([]ApprovalDelegation, error) {
+	tx := s.db.WithContext(ctx).Model(&ApprovalDelegation{})
+	if filter.DelegatorID != nil {
+		tx = tx.Where("delegator_id = ?", *filter.DelegatorID)
+	}
+	if filter.DelegateID != nil {
+		tx = tx.Where("delegate_id = ?", *filter.DelegateID)
+	}
+	if filter.ActiveOn != nil {
+		day := truncateDay(*filter.ActiveOn)
+		tx = tx.Where("starts_on <= ? AND ends_on >= ?", day, day)
+	}
+	var delegations []ApprovalDelegation
+	if err := tx.Order("starts_on DESC, id DESC").Find(&delegations).Error; err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+func (s *DelegationService) DeleteDelegation(ctx context.Context, id uint) error {
+	res := s.db.WithContext(ctx).Delete(&ApprovalDelegation{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReviewScope collects the projects the user may review at the given time:
+// the projects they own or manage as a member (when includeOwned, i.e. they
+// hold the reviewer role) plus those covered by delegations active on that
+// day. Delegations follow ownership, so a project handed to someone else
+// drops out.
+func (s *DelegationService) ReviewScope(ctx context.Context, reviewerID uint, includeOwned bool, at time.Time) (*ReviewScope, error) {
+	db := s.db.WithContext(ctx)
+	day := truncateDay(at)
+
+	var delegations []ApprovalDelegation
+	if err := db.Where("delegate_id = ? AND starts_on <= ? AND ends_on >= ?", reviewerID, day, day).
+		Find(&delegations).Error; err != nil {
+		return nil, err
+	}
+
+	conds := make([]string, 0, len(delegations)+1)
+	args := make([]interface{}, 0, 2*len(delegations)+1)
+	if includeOwned {
+		// co-managers (manager members) review like the owner
+		conds = append(conds, "owner_id = ?", "id IN (?)")
+		args = append(args, reviewerID, db.Model(&ProjectMember{}).
+			Select("project_id").
+			Where("user_id = ? AND role = ?", reviewerID, ProjectManager))
+	}
+	for _, d := range delegations {
+		if d.ProjectID != nil {
+			conds = append(conds, "(id = ? AND owner_id = ?)")
+			args = append(args, *d.ProjectID, d.DelegatorID)
+		} else {
+			conds = append(conds, "owner_id = ?")
+			args = append(args, d.DelegatorID)
+		}
+	}
+
+	scope := &ReviewScope{ReviewerID: reviewerID}
+	if len(conds) == 0 {
+		return scope, nil
+	}
+	if err := db.Model(&Project{}).
+		Where(strings.Join(conds, " OR "), args...).
+		Order("id").
+		Pluck("id", &scope.ProjectIDs).Error; err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
@@ -0,0 +1,111 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDelegationService_ReviewScope(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	delegationSvc := NewDelegationService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().AddDate(0, 0, -10)
+	const ownerA, ownerB, substitute, worker = 2, 3, 4, 7
+	projectA, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "A", ClientName: "ACME", StartDate: start, OwnerID: ownerA})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	projectB, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "B", ClientName: "ACME", StartDate: start, OwnerID: ownerB})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	scope, err := delegationSvc.ReviewScope(ctx, ownerA, true, time.Now())
+	if err != nil {
+		t.Fatalf("review scope: %v", err)
+	}
+	if !scope.Allows(projectA.ID, worker) || scope.Allows(projectB.ID, worker) {
+		t.Fatalf("owner must review only their project, got %+v", scope)
+	}
+	if scope.Allows(projectA.ID, ownerA) {
+		t.Fatalf("owner must never review their own entries")
+	}
+	withoutRole, err := delegationSvc.ReviewScope(ctx, ownerA, false, time.Now())
+	if err != nil {
+		t.Fatalf("review scope: %v", err)
+	}
+	if withoutRole.Allows(projectA.ID, worker) {
+		t.Fatalf("owners outside reviewers-group must not review")
+	}
+
+	if _, err := delegationSvc.CreateDelegation(ctx, DelegationInput{DelegatorID: ownerA, DelegateID: substitute, ProjectID: &projectB.ID, StartsOn: start, EndsOn: time.Now(), CreatedBy: 1}); err == nil {
+		t.Fatalf("expected delegation of a project not owned by the delegator to fail")
+	}
+	if _, err := delegationSvc.CreateDelegation(ctx, DelegationInput{DelegatorID: ownerA, DelegateID: substitute, StartsOn: time.Now().AddDate(0, 0, 1), EndsOn: time.Now().AddDate(0, 0, 5), CreatedBy: 1}); err != nil {
+		t.Fatalf("create future delegation: %v", err)
+	}
+	scope, err = delegationSvc.ReviewScope(ctx, substitute, false, time.Now())
+	if err != nil {
+		t.Fatalf("review scope: %v", err)
+	}
+	if scope.Allows(projectA.ID, worker) {
+		t.Fatalf("future delegation must not be active yet")
+	}
+	if _, err := delegationSvc.CreateDelegation(ctx, DelegationInput{DelegatorID: ownerA, DelegateID: substitute, StartsOn: time.Now(), EndsOn: time.Now(), Reason: "Férias", CreatedBy: 1}); err != nil {
+		t.Fatalf("create delegation: %v", err)
+	}
+	scope, err = delegationSvc.ReviewScope(ctx, substitute, false, time.Now())
+	if err != nil {
+		t.Fatalf("review scope: %v", err)
+	}
+	if !scope.Allows(projectA.ID, worker) || scope.Allows(projectB.ID, worker) {
+		t.Fatalf("substitute must review the delegator's projects only, got %+v", scope)
+	}
+
+	taskA, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: projectA.ID, Title: "Login", AssigneeID: worker})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	taskB, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: projectB.ID, Title: "Login", AssigneeID: worker})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	var ids []uint
+	for _, in := range []TimeEntryInput{
+		{TaskID: taskA.ID, UserID: worker, EntryDate: start.AddDate(0, 0, 1), Hours: 2},
+		{TaskID: taskA.ID, UserID: substitute, EntryDate: start.AddDate(0, 0, 1), Hours: 2},
+		{TaskID: taskB.ID, UserID: worker, EntryDate: start.AddDate(0, 0, 1), Hours: 2},
+	} {
+		entry, err := timeSvc.LogTime(ctx, in)
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	queue, err := timeSvc.ListEntries(ctx, scope.Apply(TimeEntryFilter{}))
+	if err != nil {
+		t.Fatalf("list reviewable: %v", err)
+	}
+	if queue.Total != 1 || queue.Items[0].ID != ids[0] {
+		t.Fatalf("expected only the worker entry of project A in the queue, got %+v", queue.Items)
+	}
+
+	result, err := timeSvc.BulkReview(ctx, BulkReviewInput{Action: BulkApprove, IDs: ids, ReviewerID: substitute, Scope: scope})
+	if err != nil {
+		t.Fatalf("bulk review: %v", err)
+	}
+	if result.Succeeded != 1 || result.Failed != 2 {
+		t.Fatalf("expected 1 approved and 2 forbidden, got %+v", result)
+	}
+	for _, item := range result.Items[1:] {
+		if item.Outcome != BulkOutcomeForbidden {
+			t.Fatalf("expected forbidden outcome, got %+v", item)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAttachmentTooLarge rejects uploads above AttachmentLimits.MaxBytes.
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	// ErrAttachmentTypeNotAllowed rejects content types outside
+	// AttachmentLimits.AllowedTypes.
+	ErrAttachmentTypeNotAllowed = errors.New("attachment content type is not allowed")
+	// ErrProjectHasAttachments prevents deleting projects whose tasks still
+	// have files in the blob store. The task_attachments foreign key does not
+	// cascade for the same reason: a cascade would drop the records and
+	// orphan the files.
+	ErrProjectHasAttachments = errors.New("project has task attachments; delete them first")
+	// ErrBlobStore wraps failures of the BlobStore.
+	ErrBlobStore = errors.New("attachment storage failed")
+)
+
+// BlobStore keeps the content of attachments. Get returns an error matching
+// fs.ErrNotExist for missing keys; Delete ignores them.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentLimits bounds uploads. An empty AllowedTypes accepts any type.
+type AttachmentLimits struct {
+	MaxBytes     int64
+	AllowedTypes []string
+}
+
+// AttachmentInput is an upload. ContentType may be empty, in which case it is
+// detected from the content.
+type AttachmentInput struct {
+	TaskID      uint
+	FileName    string
+	ContentType string
+	UploadedBy  uint
+	Body        io.Reader
+}
+
+// AttachmentService stores task attachments: metadata in the database and
+// content in a BlobStore.
+type AttachmentService struct {
+	db     *gorm.DB
+	store  BlobStore
+	limits AttachmentLimits
+}
+
+func NewAttachmentService(db *gorm.DB, store BlobStore, limits AttachmentLimits) *AttachmentService {
+	return &AttachmentService{db: db, store: store, limits: limits}
+}
+
+// Limits returns the upload limits of the service.
+func (s *AttachmentService) Limits() AttachmentLimits {
+	return s.limits
+}
+
+// Upload checks the size and type of the file, records its SHA-256 checksum
+// and stores it. The content is buffered, which the size limit keeps bounded.
+func (s *AttachmentService) Upload(ctx context.Context, in AttachmentInput) (*TaskAttachment, error) {
+	name := path.Base(strings.ReplaceAll(strings.TrimSpace(in.FileName), `\`, "/"))
+	if name == "" || name == "." || name == "/" {
+		return nil, errors.New("file name is required")
+	}
+	if len(name) > 255 {
+		return nil, errors.New("file name is too long")
+	}
+
+	var task Task
+	if err := s.db.WithContext(ctx).Preload("Project").First(&task, in.TaskID).Error; err != nil {
+		return nil, err
+	}
+	if task.Project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
+
+	body := in.Body
+	if s.limits.MaxBytes > 0 {
+		body = io.LimitReader(body, s.limits.MaxBytes+1)
+	}
+	var buf bytes.Buffer
+	n, err := io.Copy(&buf, body)
+	if err != nil {
+		return nil, err
+	}
+	if s.limits.MaxBytes > 0 && n > s.limits.MaxBytes {
+		return nil, ErrAttachmentTooLarge
+	}
+	if n == 0 {
+		return nil, errors.New("file is empty")
+	}
+	contentType, err := s.contentType(in.ContentType, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	attachment := TaskAttachment{
+		TaskID:      task.ID,
+		FileName:    name,
+		ContentType: contentType,
+		SizeBytes:   n,
+		Checksum:    hex.EncodeToString(sum[:]),
+		StorageKey:  fmt.Sprintf("tasks/%d/%s", task.ID, hex.EncodeToString(token)),
+		UploadedBy:  in.UploadedBy,
+	}
+	if err := s.store.Put(ctx, attachment.StorageKey, bytes.NewReader(buf.Bytes()), n, contentType); err != nil {
+		return nil, blobError(err)
+	}
+	if err := s.db.WithContext(ctx).Create(&attachment).Error; err != nil {
+		// o arquivo não tem registro; remove para não deixar órfão
+		_ = s.store.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ListAttachments returns the attachments of a task, newest first.
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID uint) ([]TaskAttachment, error) {
+	var attachments []TaskAttachment
+	if err := s.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("created_at DESC, id DESC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAttachment fetches the metadata of an attachment of a task.
+func (s *AttachmentService) GetAttachment(ctx context.Context, taskID, id uint) (*TaskAttachment, error) {
+	var attachment TaskAttachment
+	if err := s.db.WithContext(ctx).Where("task_id = ?", taskID).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Open returns the content of an attachment; the caller closes it.
+func (s *AttachmentService) Open(ctx context.Context, attachment *TaskAttachment) (io.ReadCloser, error) {
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, blobError(err)
+	}
+	return content, nil
+}
+
+// DeleteAttachment removes the record and then the stored content. The blob
+// is only deleted once the record is gone, so a failing blob store leaves an
+// orphaned file behind, never a record without content.
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID, id uint) error {
+	var attachment TaskAttachment
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var task Task
+		if err := tx.Preload("Project").First(&task, taskID).Error; err != nil {
+			return err
+		}
+		if task.Project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if err := tx.Where("task_id = ?", taskID).First(&attachment, id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&attachment).Error
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		return blobError(err)
+	}
+	return nil
+}
+
+// contentType uses the declared media type, or sniffs the content when none
+// was sent, and checks it against the allowed types.
+func (s *AttachmentService) contentType(declared string, content []byte) (string, error) {
+	mediaType := ""
+	if declared != "" {
+		parsed, _, err := mime.ParseMediaType(declared)
+		if err != nil {
+			return "", fmt.Errorf("invalid content type %q", declared)
+		}
+		mediaType = parsed
+	}
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(content))
+	}
+	if len(s.limits.AllowedTypes) == 0 {
+		return mediaType, nil
+	}
+	for _, allowed := range s.limits.AllowedTypes {
+		if strings.EqualFold(allowed, mediaType) {
+			return mediaType, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, mediaType)
+}
+
+// blobError marks store failures while keeping fs.ErrNotExist recognizable.
+func blobError(err error) error {
+	return fmt.Errorf("%w: %w", ErrBlobStore, err)
+}
@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryStore is an in-memory BlobStore. deleteErr makes Delete fail.
+type memoryStore struct {
+	blobs     map[string][]byte
+	deleteErr error
+}
+
+func (m *memoryStore) Put(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.blobs[key] = data
+	return nil
+}
+
+func (m *memoryStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, key string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	delete(m.blobs, key)
+	return nil
+}
+
+func TestAttachmentService_UploadLimitsChecksumAndDelete(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	store := &memoryStore{blobs: map[string][]byte{}}
+	svc := NewAttachmentService(db, store, AttachmentLimits{MaxBytes: 64, AllowedTypes: []string{"text/plain", "application/pdf"}})
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	content := "ata da reunião"
+	attachment, err := svc.Upload(ctx, AttachmentInput{TaskID: task.ID, FileName: `C:\docs\ata.txt`, UploadedBy: 7, Body: strings.NewReader(content)})
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	sum := sha256.Sum256([]byte(content))
+	if attachment.FileName != "ata.txt" || attachment.ContentType != "text/plain" || attachment.SizeBytes != int64(len(content)) || attachment.Checksum != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected attachment metadata: %+v", attachment)
+	}
+	if string(store.blobs[attachment.StorageKey]) != content {
+		t.Fatalf("expected content under %q", attachment.StorageKey)
+	}
+
+	if _, err := svc.Upload(ctx, AttachmentInput{TaskID: task.ID, FileName: "big.txt", UploadedBy: 7, Body: strings.NewReader(strings.Repeat("a", 65))}); !errors.Is(err, ErrAttachmentTooLarge) {
+		t.Fatalf("expected ErrAttachmentTooLarge, got %v", err)
+	}
+	if _, err := svc.Upload(ctx, AttachmentInput{TaskID: task.ID, FileName: "logo.png", ContentType: "image/png", UploadedBy: 7, Body: strings.NewReader("png")}); !errors.Is(err, ErrAttachmentTypeNotAllowed) {
+		t.Fatalf("expected ErrAttachmentTypeNotAllowed, got %v", err)
+	}
+	if len(store.blobs) != 1 {
+		t.Fatalf("expected rejected uploads not to be stored, got %d blobs", len(store.blobs))
+	}
+
+	attachments, err := svc.ListAttachments(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("list attachments: %v", err)
+	}
+	if len(attachments) != 1 {
+		t.Fatalf("expected one attachment, got %d", len(attachments))
+	}
+	body, err := svc.Open(ctx, &attachments[0])
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != content {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	if err := projectSvc.DeleteProject(ctx, project.ID); !errors.Is(err, ErrProjectHasAttachments) {
+		t.Fatalf("expected ErrProjectHasAttachments, got %v", err)
+	}
+	if err := svc.DeleteAttachment(ctx, task.ID, attachment.ID); err != nil {
+		t.Fatalf("delete attachment: %v", err)
+	}
+	if len(store.blobs) != 0 {
+		t.Fatal("expected the blob to be removed")
+	}
+	if _, err := svc.Open(ctx, attachment); !errors.Is(err, fs.ErrNotExist) || !errors.Is(err, ErrBlobStore) {
+		t.Fatalf("expected a missing blob error, got %v", err)
+	}
+
+	// o blob só é apagado depois do commit: uma falha no armazenamento deixa
+	// o arquivo órfão, nunca um registro sem conteúdo
+	orphan, err := svc.Upload(ctx, AttachmentInput{TaskID: task.ID, FileName: "notas.txt", UploadedBy: 7, Body: strings.NewReader("notas")})
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	store.deleteErr = errors.New("bucket offline")
+	if err := svc.DeleteAttachment(ctx, task.ID, orphan.ID); !errors.Is(err, ErrBlobStore) {
+		t.Fatalf("expected ErrBlobStore, got %v", err)
+	}
+	if _, err := svc.GetAttachment(ctx, task.ID, orphan.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected the record to be deleted before the blob, got %v", err)
+	}
+	if len(store.blobs) != 1 {
+		t.Fatalf("expected the blob to be left behind, got %d blobs", len(store.blobs))
+	}
+}
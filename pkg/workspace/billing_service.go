@@ -0,0 +1,395 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrEntryInvoiced is returned when an invoiced entry would be changed.
+var ErrEntryInvoiced = errors.New("time entry is already invoiced")
+
+// BillingRateInput defines a new hourly rate.
+type BillingRateInput struct {
+	ProjectID     *uint
+	UserID        *uint
+	HourlyRate    float64
+	Currency      string
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+}
+
+// BillingRateFilter holds filters for rate listings.
+type BillingRateFilter struct {
+	ProjectID *uint
+	UserID    *uint
+}
+
+// InvoiceInput selects the project and period to invoice.
+type InvoiceInput struct {
+	ProjectID   uint
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	CreatedBy   uint
+}
+
+// InvoicesPage wraps paginated invoices.
+type InvoicesPage struct {
+	Items []Invoice
+	Total int64
+}
+
+// InvoiceFilter holds filters for invoice listings.
+type InvoiceFilter struct {
+	ProjectID *uint
+	Status    []InvoiceStatus
+	Page      int
+	PageSize  int
+}
+
+// BillingService turns approved hours into invoices.
+type BillingService struct {
+	db *gorm.DB
+}
+
+func NewBillingService(db *gorm.DB) *BillingService {
+	return &BillingService{db: db}
+}
+
+func (s *BillingService) CreateRate(ctx context.Context, in BillingRateInput) (*BillingRate, error) {
+	if in.ProjectID == nil && in.UserID == nil {
+		return nil, errors.New("rate must target a project, a user or both")
+	}
+	if math.IsNaN(in.HourlyRate) || math.IsInf(in.HourlyRate, 0) || in.HourlyRate <= 0 {
+		return nil, errors.New("hourly rate must be greater than zero")
+	}
+	if in.EffectiveFrom.IsZero() {
+		return nil, errors.New("effective from date is required")
+	}
+	if in.EffectiveTo != nil && in.EffectiveTo.Before(in.EffectiveFrom) {
+		return nil, errors.New("effective to cannot be before effective from")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(in.Currency))
+	if currency == "" {
+		currency = "BRL"
+	}
+	if len(currency) != 3 {
+		return nil, errors.New("currency must be a 3-letter ISO code")
+	}
+	if in.ProjectID != nil {
+		if err := s.db.WithContext(ctx).First(&Project{}, *in.ProjectID).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	rate := &BillingRate{
+		ProjectID:     in.ProjectID,
+		UserID:        in.UserID,
+		HourlyRate:    math.Round(in.HourlyRate*100) / 100,
+		Currency:      currency,
+		EffectiveFrom: in.EffectiveFrom,
+		EffectiveTo:   in.EffectiveTo,
+	}
+	if err := s.db.WithContext(ctx).Create(rate).Error; err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *BillingService) ListRates(ctx context.Context, filter BillingRateFilter) ([]BillingRate, error) {
+	tx := s.db.WithContext(ctx).Model(&BillingRate{})
+	if filter.ProjectID != nil {
+		tx = tx.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.UserID != nil {
+		tx = tx.Where("user_id = ?", *filter.UserID)
+	}
+	var rates []BillingRate
+	if err := tx.Order("effective_from DESC, id DESC").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (s *BillingService) DeleteRate(ctx context.Context, id uint) error {
+	res := s.db.WithContext(ctx).Delete(&BillingRate{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateInvoice builds a draft invoice from the approved, not yet invoiced
+// entries of the project whose date falls within the period (inclusive).
+func (s *BillingService) CreateInvoice(ctx context.Context, in InvoiceInput) (*Invoice, error) {
+	if in.ProjectID == 0 {
+		return nil, errors.New("project is required")
+	}
+	if in.CreatedBy == 0 {
+		return nil, errors.New("creator is required")
+	}
+	if in.PeriodStart.IsZero() || in.PeriodEnd.IsZero() {
+		return nil, errors.New("invoice period is required")
+	}
+	periodStart := truncateDay(in.PeriodStart)
+	periodEnd := truncateDay(in.PeriodEnd)
+	if periodEnd.Before(periodStart) {
+		return nil, errors.New("period end cannot be before period start")
+	}
+
+	var invoice *Invoice
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var project Project
+		if err := tx.First(&project, in.ProjectID).Error; err != nil {
+			return err
+		}
+		if project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+
+		var entries []TimeEntry
+		if err := tx.Where("task_id IN (?)", tx.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id = ?", project.ID)).
+			Where("status = ? AND invoice_id IS NULL", TimeEntryApproved).
+			Where("entry_date >= ? AND entry_date < ?", periodStart, periodEnd.AddDate(0, 0, 1)).
+			Order("entry_date, id").
+			Find(&entries).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return errors.New("no approved entries to invoice in the period")
+		}
+
+		var rates []BillingRate
+		if err := tx.Where("project_id = ? OR project_id IS NULL", project.ID).
+			Find(&rates).Error; err != nil {
+			return err
+		}
+
+		invoice = &Invoice{
+			ProjectID:   project.ID,
+			ClientName:  project.ClientName,
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			Status:      InvoiceDraft,
+			CreatedBy:   in.CreatedBy,
+		}
+		ids := make([]uint, 0, len(entries))
+		for _, entry := range entries {
+			rate := resolveBillingRate(rates, project.ID, entry.UserID, entry.EntryDate)
+			if rate == nil {
+				return fmt.Errorf("no billing rate for user %d on %s", entry.UserID, entry.EntryDate.UTC().Format("2006-01-02"))
+			}
+			if invoice.Currency == "" {
+				invoice.Currency = rate.Currency
+			} else if invoice.Currency != rate.Currency {
+				return errors.New("entries are billed in different currencies")
+			}
+			amount := math.Round(entry.Hours*rate.HourlyRate*100) / 100
+			invoice.Lines = append(invoice.Lines, InvoiceLine{
+				TimeEntryID: entry.ID,
+				TaskID:      entry.TaskID,
+				UserID:      entry.UserID,
+				EntryDate:   entry.EntryDate,
+				Description: entry.Notes,
+				Hours:       entry.Hours,
+				Rate:        rate.HourlyRate,
+				Amount:      amount,
+			})
+			invoice.TotalHours += entry.Hours
+			invoice.TotalAmount += amount
+			ids = append(ids, entry.ID)
+		}
+		invoice.TotalHours = roundHours(invoice.TotalHours)
+		invoice.TotalAmount = math.Round(invoice.TotalAmount*100) / 100
+
+		if err := tx.Create(invoice).Error; err != nil {
+			return err
+		}
+		invoice.Number = fmt.Sprintf("INV-%d-%05d", invoice.CreatedAt.Year(), invoice.ID)
+		if err := tx.Model(invoice).Update("number", invoice.Number).Error; err != nil {
+			return err
+		}
+
+		// a condição em invoice_id impede que outra fatura leve os mesmos lançamentos
+		res := tx.Model(&TimeEntry{}).
+			Where("id IN ? AND invoice_id IS NULL", ids).
+			Update("invoice_id", invoice.ID)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(ids)) {
+			return ErrEntryInvoiced
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+func (s *BillingService) GetInvoice(ctx context.Context, id uint) (*Invoice, error) {
+	var invoice Invoice
+	if err := s.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("entry_date, id") }).
+		First(&invoice, id).Error; err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (s *BillingService) ListInvoices(ctx context.Context, filter InvoiceFilter) (InvoicesPage, error) {
+	filter = sanitizeInvoiceFilter(filter)
+
+	tx := s.db.WithContext(ctx).Model(&Invoice{})
+	if filter.ProjectID != nil {
+		tx = tx.Where("project_id = ?", *filter.ProjectID)
+	}
+	if len(filter.Status) > 0 {
+		tx = tx.Where("status IN ?", filter.Status)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return InvoicesPage{}, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	var items []Invoice
+	if err := tx.Order("created_at DESC, id DESC").
+		Limit(filter.PageSize).
+		Offset(offset).
+		Find(&items).Error; err != nil {
+		return InvoicesPage{}, err
+	}
+
+	return InvoicesPage{Items: items, Total: total}, nil
+}
+
+// IssueInvoice freezes a draft invoice so it can be sent to the client.
+func (s *BillingService) IssueInvoice(ctx context.Context, id uint) (*Invoice, error) {
+	return s.transitionInvoice(ctx, id, InvoiceIssued)
+}
+
+func (s *BillingService) MarkPaid(ctx context.Context, id uint) (*Invoice, error) {
+	return s.transitionInvoice(ctx, id, InvoicePaid)
+}
+
+// VoidInvoice cancels an unpaid invoice and releases its entries so they can
+// be invoiced again. Lines are kept for audit.
+func (s *BillingService) VoidInvoice(ctx context.Context, id uint) (*Invoice, error) {
+	return s.transitionInvoice(ctx, id, InvoiceVoid)
+}
+
+func (s *BillingService) transitionInvoice(ctx context.Context, id uint, next InvoiceStatus) (*Invoice, error) {
+	var invoice Invoice
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&invoice, id).Error; err != nil {
+			return err
+		}
+		if !canTransitionInvoice(invoice.Status, next) {
+			return fmt.Errorf("invalid invoice transition from %s to %s", invoice.Status, next)
+		}
+		now := time.Now().UTC()
+		invoice.Status = next
+		switch next {
+		case InvoiceIssued:
+			invoice.IssuedAt = &now
+		case InvoicePaid:
+			invoice.PaidAt = &now
+		case InvoiceVoid:
+			invoice.VoidedAt = &now
+			if err := tx.Model(&TimeEntry{}).
+				Where("invoice_id = ?", invoice.ID).
+				Update("invoice_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&invoice).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetInvoice(ctx, invoice.ID)
+}
+
+func canTransitionInvoice(current, next InvoiceStatus) bool {
+	switch current {
+	case InvoiceDraft:
+		return next == InvoiceIssued || next == InvoiceVoid
+	case InvoiceIssued:
+		return next == InvoicePaid || next == InvoiceVoid
+	default:
+		return false
+	}
+}
+
+// resolveBillingRate picks the most specific rate in effect on the date:
+// project+user, then user, then project. Ties go to the latest start.
+func resolveBillingRate(rates []BillingRate, projectID, userID uint, date time.Time) *BillingRate {
+	day := truncateDay(date)
+	candidates := make([]BillingRate, 0, len(rates))
+	for _, rate := range rates {
+		if rate.ProjectID != nil && *rate.ProjectID != projectID {
+			continue
+		}
+		if rate.UserID != nil && *rate.UserID != userID {
+			continue
+		}
+		if rate.ProjectID == nil && rate.UserID == nil {
+			continue
+		}
+		if truncateDay(rate.EffectiveFrom).After(day) {
+			continue
+		}
+		if rate.EffectiveTo != nil && truncateDay(*rate.EffectiveTo).Before(day) {
+			continue
+		}
+		candidates = append(candidates, rate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := rateSpecificity(candidates[i]), rateSpecificity(candidates[j])
+		if si != sj {
+			return si > sj
+		}
+		return candidates[i].EffectiveFrom.After(candidates[j].EffectiveFrom)
+	})
+	return &candidates[0]
+}
+
+func rateSpecificity(rate BillingRate) int {
+	switch {
+	case rate.ProjectID != nil && rate.UserID != nil:
+		return 3
+	case rate.UserID != nil:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func sanitizeInvoiceFilter(filter InvoiceFilter) InvoiceFilter {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	return filter
+}
@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBillingService_InvoiceLifecycle(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	billingSvc := NewBillingService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().AddDate(0, 0, -10)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Portal",
+		ClientName:  "ACME",
+		Description: "Portal do cliente",
+		StartDate:   start,
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Tela de login",
+		AssigneeID: 7,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	if _, err := billingSvc.CreateRate(ctx, BillingRateInput{ProjectID: &project.ID, HourlyRate: 100, EffectiveFrom: start}); err != nil {
+		t.Fatalf("project rate: %v", err)
+	}
+	userID := uint(7)
+	if _, err := billingSvc.CreateRate(ctx, BillingRateInput{ProjectID: &project.ID, UserID: &userID, HourlyRate: 150, EffectiveFrom: start}); err != nil {
+		t.Fatalf("project+user rate: %v", err)
+	}
+
+	var entries []*TimeEntry
+	for i := 0; i < 2; i++ {
+		entry, err := timeSvc.LogTime(ctx, TimeEntryInput{
+			TaskID:    task.ID,
+			UserID:    7,
+			EntryDate: start.AddDate(0, 0, i+1),
+			Hours:     2,
+			Notes:     "Desenvolvimento",
+		})
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+		if _, err := timeSvc.ApproveEntry(ctx, entry.ID, 1); err != nil {
+			t.Fatalf("approve: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	invoice, err := billingSvc.CreateInvoice(ctx, InvoiceInput{
+		ProjectID:   project.ID,
+		PeriodStart: start,
+		PeriodEnd:   time.Now().UTC(),
+		CreatedBy:   1,
+	})
+	if err != nil {
+		t.Fatalf("create invoice: %v", err)
+	}
+	if len(invoice.Lines) != 2 || invoice.TotalHours != 4 || invoice.TotalAmount != 600 {
+		t.Fatalf("unexpected invoice totals: %+v", invoice)
+	}
+	if invoice.Number == "" || invoice.ClientName != "ACME" || invoice.Currency != "BRL" {
+		t.Fatalf("unexpected invoice header: %+v", invoice)
+	}
+
+	if _, err := billingSvc.CreateInvoice(ctx, InvoiceInput{
+		ProjectID:   project.ID,
+		PeriodStart: start,
+		PeriodEnd:   time.Now().UTC(),
+		CreatedBy:   1,
+	}); err == nil {
+		t.Fatal("expected error when re-invoicing the same entries")
+	}
+	if _, err := timeSvc.ReopenEntry(ctx, entries[0].ID, 1, "ajuste"); !errors.Is(err, ErrEntryInvoiced) {
+		t.Fatalf("expected invoiced entry guard, got %v", err)
+	}
+
+	if _, err := billingSvc.MarkPaid(ctx, invoice.ID); err == nil {
+		t.Fatal("draft invoice cannot be paid")
+	}
+	if _, err := billingSvc.IssueInvoice(ctx, invoice.ID); err != nil {
+		t.Fatalf("issue invoice: %v", err)
+	}
+	voided, err := billingSvc.VoidInvoice(ctx, invoice.ID)
+	if err != nil {
+		t.Fatalf("void invoice: %v", err)
+	}
+	if voided.Status != InvoiceVoid || len(voided.Lines) != 2 {
+		t.Fatalf("void should keep lines for audit: %+v", voided)
+	}
+	if _, err := timeSvc.ReopenEntry(ctx, entries[0].ID, 1, "ajuste"); err != nil {
+		t.Fatalf("entry should be released after void: %v", err)
+	}
+
+	if err := projectSvc.DeleteProject(ctx, project.ID); !errors.Is(err, ErrProjectHasInvoices) {
+		t.Fatalf("expected delete guard, got %v", err)
+	}
+}
+
+func TestResolveBillingRate(t *testing.T) {
+	projectID, otherProject, userID := uint(1), uint(2), uint(9)
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rates := []BillingRate{
+		{ID: 1, ProjectID: &projectID, HourlyRate: 100, EffectiveFrom: jan},
+		{ID: 2, UserID: &userID, HourlyRate: 120, EffectiveFrom: jan},
+		{ID: 3, ProjectID: &projectID, UserID: &userID, HourlyRate: 150, EffectiveFrom: jan, EffectiveTo: ptrTime(mar.AddDate(0, 0, -1))},
+		{ID: 4, ProjectID: &projectID, UserID: &userID, HourlyRate: 170, EffectiveFrom: mar},
+		{ID: 5, ProjectID: &otherProject, UserID: &userID, HourlyRate: 999, EffectiveFrom: jan},
+	}
+
+	cases := []struct {
+		name   string
+		userID uint
+		date   time.Time
+		want   uint
+	}{
+		{name: "project and user", userID: userID, date: jan.AddDate(0, 1, 0), want: 3},
+		{name: "newer project and user", userID: userID, date: mar.AddDate(0, 0, 5), want: 4},
+		{name: "project only", userID: 3, date: mar, want: 1},
+		{name: "before any rate", userID: userID, date: jan.AddDate(0, 0, -1), want: 0},
+	}
+	for _, tc := range cases {
+		rate := resolveBillingRate(rates, projectID, tc.userID, tc.date)
+		got := uint(0)
+		if rate != nil {
+			got = rate.ID
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected rate %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
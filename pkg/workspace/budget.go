@@ -0,0 +1,284 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrBudgetExhausted is returned when an entry would go over a hard budget.
+var ErrBudgetExhausted = errors.New("project budget exhausted")
+
+// defaultBudgetThresholds apply when a budget is set without thresholds.
+var defaultBudgetThresholds = []int{80, 100}
+
+// BudgetInput sets the budget of a project. Zero values remove the
+// corresponding budget; Hard blocks entries beyond the budget hours.
+type BudgetInput struct {
+	Hours      float64
+	Amount     float64
+	Currency   string
+	Thresholds []int
+	Hard       bool
+}
+
+// BudgetAlert tells whether a threshold of the hour or amount budget has been
+// reached.
+type BudgetAlert struct {
+	Metric    string
+	Threshold int
+	Reached   bool
+}
+
+// TaskBurn compares the hours logged on a task with its estimate.
+type TaskBurn struct {
+	TaskID        uint
+	Title         string
+	EstimateHours float64
+	ApprovedHours float64
+	PendingHours  float64
+	PercentUsed   float64
+	OverEstimate  bool
+}
+
+// ProjectBurn compares approved plus pending hours, and their value at the
+// billing rates in effect, with the project budget. Hours without a rate in
+// the budget currency are reported as UnpricedHours.
+type ProjectBurn struct {
+	ProjectID         uint
+	BudgetHours       float64
+	ApprovedHours     float64
+	PendingHours      float64
+	UsedHours         float64
+	RemainingHours    float64
+	PercentUsed       float64
+	BudgetAmount      float64
+	Currency          string
+	UsedAmount        float64
+	RemainingAmount   float64
+	AmountPercentUsed float64
+	UnpricedHours     float64
+	HardBudget        bool
+	Alerts            []BudgetAlert
+	Tasks             []TaskBurn
+}
+
+// SetBudget replaces the budget settings of a project.
+func (s *ProjectService) SetBudget(ctx context.Context, projectID uint, in BudgetInput) (*Project, error) {
+	in.Currency = strings.ToUpper(strings.TrimSpace(in.Currency))
+	if in.Currency == "" {
+		in.Currency = "BRL"
+	}
+	if err := validateBudgetInput(in); err != nil {
+		return nil, err
+	}
+	thresholds := in.Thresholds
+	if thresholds == nil {
+		thresholds = defaultBudgetThresholds
+	}
+	thresholds = append([]int(nil), thresholds...)
+	sort.Ints(thresholds)
+
+	var project Project
+	if err := s.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
+		return nil, err
+	}
+	if project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
+	project.BudgetHours = in.Hours
+	project.BudgetAmount = in.Amount
+	project.BudgetCurrency = in.Currency
+	project.BudgetThresholds = thresholds
+	project.HardBudget = in.Hard
+	if err := s.db.WithContext(ctx).Save(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// Burn reports how much of the project budget is used by approved and
+// pending entries, overall and per task.
+func (s *ProjectService) Burn(ctx context.Context, projectID uint) (*ProjectBurn, error) {
+	db := s.db.WithContext(ctx)
+
+	var project Project
+	if err := db.First(&project, projectID).Error; err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	if err := db.Where("project_id = ?", project.ID).Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	var entries []TimeEntry
+	if err := db.Where("task_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+		Model(&Task{}).Select("id").Where("project_id = ?", project.ID)).
+		Where("status <> ?", TimeEntryRejected).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	var rates []BillingRate
+	if err := db.Where("project_id = ? OR project_id IS NULL", project.ID).
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	burn := &ProjectBurn{
+		ProjectID:    project.ID,
+		BudgetHours:  project.BudgetHours,
+		BudgetAmount: project.BudgetAmount,
+		Currency:     project.BudgetCurrency,
+		HardBudget:   project.HardBudget,
+	}
+	byTask := make(map[uint]*TaskBurn, len(tasks))
+	burn.Tasks = make([]TaskBurn, len(tasks))
+	for i, task := range tasks {
+		burn.Tasks[i] = TaskBurn{TaskID: task.ID, Title: task.Title, EstimateHours: task.EstimateHours}
+		byTask[task.ID] = &burn.Tasks[i]
+	}
+
+	for _, entry := range entries {
+		taskBurn := byTask[entry.TaskID]
+		if entry.Status == TimeEntryApproved {
+			burn.ApprovedHours += entry.Hours
+			if taskBurn != nil {
+				taskBurn.ApprovedHours += entry.Hours
+			}
+		} else {
+			burn.PendingHours += entry.Hours
+			if taskBurn != nil {
+				taskBurn.PendingHours += entry.Hours
+			}
+		}
+		rate := resolveBillingRate(rates, project.ID, entry.UserID, entry.EntryDate)
+		if rate == nil || rate.Currency != project.BudgetCurrency {
+			burn.UnpricedHours += entry.Hours
+			continue
+		}
+		burn.UsedAmount += entry.Hours * rate.HourlyRate
+	}
+
+	burn.ApprovedHours = roundHours(burn.ApprovedHours)
+	burn.PendingHours = roundHours(burn.PendingHours)
+	burn.UsedHours = roundHours(burn.ApprovedHours + burn.PendingHours)
+	burn.UnpricedHours = roundHours(burn.UnpricedHours)
+	burn.UsedAmount = math.Round(burn.UsedAmount*100) / 100
+
+	thresholds := project.BudgetThresholds
+	if thresholds == nil {
+		thresholds = defaultBudgetThresholds
+	}
+	if burn.BudgetHours > 0 {
+		burn.RemainingHours = roundHours(burn.BudgetHours - burn.UsedHours)
+		burn.PercentUsed = percentOf(burn.UsedHours, burn.BudgetHours)
+		burn.Alerts = append(burn.Alerts, budgetAlerts("hours", thresholds, burn.PercentUsed)...)
+	}
+	if burn.BudgetAmount > 0 {
+		burn.RemainingAmount = math.Round((burn.BudgetAmount-burn.UsedAmount)*100) / 100
+		burn.AmountPercentUsed = percentOf(burn.UsedAmount, burn.BudgetAmount)
+		burn.Alerts = append(burn.Alerts, budgetAlerts("amount", thresholds, burn.AmountPercentUsed)...)
+	}
+
+	for i := range burn.Tasks {
+		task := &burn.Tasks[i]
+		task.ApprovedHours = roundHours(task.ApprovedHours)
+		task.PendingHours = roundHours(task.PendingHours)
+		if task.EstimateHours > 0 {
+			used := roundHours(task.ApprovedHours + task.PendingHours)
+			task.PercentUsed = percentOf(used, task.EstimateHours)
+			task.OverEstimate = used > task.EstimateHours
+		}
+	}
+	return burn, nil
+}
+
+// checkBudget sums the other non-rejected entries of the project against its
+// hour budget. Hard budgets reject entries that would go over it; otherwise
+// the highest threshold reached is returned as a warning.
+func (s *TimeEntryService) checkBudget(ctx context.Context, project *Project, hours float64, excludeID uint) ([]string, error) {
+	if project.BudgetHours <= 0 {
+		return nil, nil
+	}
+
+	var used float64
+	tx := s.db.WithContext(ctx).Model(&TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("task_id IN (?)", s.db.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id = ?", project.ID)).
+		Where("status <> ?", TimeEntryRejected)
+	if excludeID != 0 {
+		tx = tx.Where("id <> ?", excludeID)
+	}
+	if err := tx.Scan(&used).Error; err != nil {
+		return nil, err
+	}
+
+	total := roundHours(used + hours)
+	if project.HardBudget && total > project.BudgetHours {
+		return nil, fmt.Errorf("%w: %.2f hours would exceed the budget of %.2f",
+			ErrBudgetExhausted, total, project.BudgetHours)
+	}
+
+	thresholds := project.BudgetThresholds
+	if thresholds == nil {
+		thresholds = defaultBudgetThresholds
+	}
+	percent := percentOf(total, project.BudgetHours)
+	reached := 0
+	for _, threshold := range thresholds {
+		if percent >= float64(threshold) {
+			reached = threshold
+		}
+	}
+	if reached == 0 {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("project budget %d%% reached: %.2f of %.2f hours",
+		reached, total, project.BudgetHours)}, nil
+}
+
+func budgetAlerts(metric string, thresholds []int, percent float64) []BudgetAlert {
+	alerts := make([]BudgetAlert, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		alerts = append(alerts, BudgetAlert{
+			Metric:    metric,
+			Threshold: threshold,
+			Reached:   percent >= float64(threshold),
+		})
+	}
+	return alerts
+}
+
+func percentOf(value, total float64) float64 {
+	return math.Round(value/total*10000) / 100
+}
+
+func validateBudgetInput(in BudgetInput) error {
+	for _, v := range []float64{in.Hours, in.Amount} {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return errors.New("budget values must be zero or positive numbers")
+		}
+	}
+	if len(in.Currency) != 3 {
+		return errors.New("currency must be a 3-letter ISO code")
+	}
+	if in.Hard && in.Hours == 0 {
+		return errors.New("a hard budget requires budget hours")
+	}
+	seen := make(map[int]bool, len(in.Thresholds))
+	for _, threshold := range in.Thresholds {
+		if threshold <= 0 || threshold > 1000 {
+			return errors.New("thresholds must be percentages between 1 and 1000")
+		}
+		if seen[threshold] {
+			return fmt.Errorf("duplicate threshold %d", threshold)
+		}
+		seen[threshold] = true
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProjectBudget_BurnAndHardLimit(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	billingSvc := NewBillingService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Portal",
+		ClientName: "ACME",
+		StartDate:  start,
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7, EstimateHours: 4})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Cadastro", AssigneeID: 7, EstimateHours: -1}); err == nil {
+		t.Fatal("expected negative estimate to be rejected")
+	}
+	if _, err := billingSvc.CreateRate(ctx, BillingRateInput{ProjectID: &project.ID, HourlyRate: 100, EffectiveFrom: start}); err != nil {
+		t.Fatalf("rate: %v", err)
+	}
+
+	if _, err := projectSvc.SetBudget(ctx, project.ID, BudgetInput{Hard: true}); err == nil {
+		t.Fatal("expected hard budget without hours to be rejected")
+	}
+	if _, err := projectSvc.SetBudget(ctx, project.ID, BudgetInput{Hours: 10, Amount: 500, Hard: true}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	first, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 3})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if len(first.Warnings) != 0 {
+		t.Fatalf("expected no warnings below thresholds, got %v", first.Warnings)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, first.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	second, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2), Hours: 5})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if len(second.Warnings) != 1 || second.Warnings[0] != "project budget 80% reached: 8.00 of 10.00 hours" {
+		t.Fatalf("expected 80%% warning, got %v", second.Warnings)
+	}
+
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 3), Hours: 3}); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted, got %v", err)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, second.ID, TimeEntryUpdateInput{EntryDate: second.EntryDate, Hours: 8}); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted on update, got %v", err)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, second.ID, 1, "horas a mais"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 3), Hours: 3}); err != nil {
+		t.Fatalf("rejected hours should free the budget: %v", err)
+	}
+
+	burn, err := projectSvc.Burn(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("burn: %v", err)
+	}
+	if burn.ApprovedHours != 3 || burn.PendingHours != 3 || burn.UsedHours != 6 || burn.RemainingHours != 4 || burn.PercentUsed != 60 {
+		t.Fatalf("unexpected hour burn: %+v", burn)
+	}
+	if burn.UsedAmount != 600 || burn.AmountPercentUsed != 120 || burn.RemainingAmount != -100 || burn.UnpricedHours != 0 {
+		t.Fatalf("unexpected amount burn: %+v", burn)
+	}
+	var reached []string
+	for _, alert := range burn.Alerts {
+		if alert.Reached {
+			reached = append(reached, fmt.Sprintf("%s %d%%", alert.Metric, alert.Threshold))
+		}
+	}
+	if len(burn.Alerts) != 4 || len(reached) != 2 || reached[0] != "amount 80%" || reached[1] != "amount 100%" {
+		t.Fatalf("expected only the amount alerts reached, got %+v", burn.Alerts)
+	}
+	if len(burn.Tasks) != 1 || burn.Tasks[0].PercentUsed != 150 || !burn.Tasks[0].OverEstimate {
+		t.Fatalf("unexpected task burn: %+v", burn.Tasks)
+	}
+}
+
+func TestResubmitEntry_ChecksBudgetAndPolicy(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := projectSvc.SetBudget(ctx, project.ID, BudgetInput{Hours: 10, Hard: true}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	// as horas rejeitadas liberam o orçamento, que é consumido por outro lançamento
+	rejected, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 6})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, rejected.ID, 1, "revisar"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 8, EntryDate: start.AddDate(0, 0, 2), Hours: 8}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, rejected.ID); !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted on resubmit, got %v", err)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, rejected.ID, TimeEntryUpdateInput{EntryDate: rejected.EntryDate, Hours: 2}); err != nil {
+		t.Fatalf("reduce rejected entry: %v", err)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, rejected.ID); err != nil {
+		t.Fatalf("resubmit within budget: %v", err)
+	}
+
+	// o mesmo vale para a política de horas do usuário
+	if _, err := projectSvc.SetBudget(ctx, project.ID, BudgetInput{}); err != nil {
+		t.Fatalf("clear budget: %v", err)
+	}
+	if _, err := timeSvc.SetUserPolicy(ctx, 9, HourPolicy{MaxDailyHours: 8, WeekendRule: WeekendAllow}); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+	day := start.AddDate(0, 0, 3)
+	late, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 9, EntryDate: day, Hours: 4})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, late.ID, 1, "revisar"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 9, EntryDate: day.Add(time.Hour), Hours: 6}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, late.ID); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected ErrPolicyViolation on resubmit, got %v", err)
+	}
+}
@@ -0,0 +1,427 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrClientNameTaken is returned when another client already uses the name,
+// ignoring case and surrounding spaces.
+var ErrClientNameTaken = errors.New("a client with this name already exists")
+
+// ErrClientHasProjects prevents deleting clients that still have projects.
+var ErrClientHasProjects = errors.New("client has projects and cannot be deleted, deactivate it instead")
+
+// ErrUnknownClient is returned when a project names a client that is not
+// registered.
+var ErrUnknownClient = errors.New("unknown client")
+
+// ErrClientInactive is returned when assigning new projects to an inactive
+// client.
+var ErrClientInactive = errors.New("client is inactive")
+
+// ClientContactInput describes a contact of a client.
+type ClientContactInput struct {
+	Name      string
+	Email     string
+	Phone     string
+	Role      string
+	IsPrimary bool
+}
+
+// ClientInput holds client data. Contacts replace the current ones on update.
+type ClientInput struct {
+	Name           string
+	LegalName      string
+	TaxID          string
+	BillingEmail   string
+	BillingAddress string
+	Currency       string
+	Notes          string
+	Active         bool
+	Contacts       []ClientContactInput
+}
+
+// ClientFilter holds list parameters.
+type ClientFilter struct {
+	Search   string
+	Active   *bool
+	Page     int
+	PageSize int
+}
+
+// ClientsPage wraps a paginated list of clients.
+type ClientsPage struct {
+	Items []Client
+	Total int64
+}
+
+// ClientProjectSummary rolls up the hours of one project of a client.
+type ClientProjectSummary struct {
+	ProjectID     uint
+	Name          string
+	Status        ProjectStatus
+	Archived      bool
+	ApprovedHours float64
+	PendingHours  float64
+}
+
+// ClientSummary rolls up the projects and non-rejected hours of a client.
+type ClientSummary struct {
+	ClientID         uint
+	Name             string
+	Projects         int
+	ProjectsByStatus map[ProjectStatus]int
+	ApprovedHours    float64
+	PendingHours     float64
+	TotalHours       float64
+	ProjectSummaries []ClientProjectSummary
+}
+
+// ClientService orchestrates use cases for clients.
+type ClientService struct {
+	db *gorm.DB
+}
+
+func NewClientService(db *gorm.DB) *ClientService {
+	return &ClientService{db: db}
+}
+
+// CreateClient registers an active client.
+func (s *ClientService) CreateClient(ctx context.Context, in ClientInput) (*Client, error) {
+	in, err := normalizeClientInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Name:           in.Name,
+		LegalName:      in.LegalName,
+		TaxID:          in.TaxID,
+		BillingEmail:   in.BillingEmail,
+		BillingAddress: in.BillingAddress,
+		Currency:       in.Currency,
+		Notes:          in.Notes,
+		Active:         true,
+		Contacts:       clientContacts(in.Contacts),
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureClientNameFree(tx, in.Name, 0); err != nil {
+			return err
+		}
+		return tx.Create(client).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// UpdateClient replaces the client data and contacts. A new name is copied to
+// the client's projects.
+func (s *ClientService) UpdateClient(ctx context.Context, id uint, in ClientInput) (*Client, error) {
+	in, err := normalizeClientInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var client Client
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&client, id).Error; err != nil {
+			return err
+		}
+		if err := ensureClientNameFree(tx, in.Name, id); err != nil {
+			return err
+		}
+		if client.Name != in.Name {
+			if err := tx.Model(&Project{}).
+				Where("client_id = ?", id).
+				Update("client_name", in.Name).Error; err != nil {
+				return err
+			}
+		}
+
+		client.Name = in.Name
+		client.LegalName = in.LegalName
+		client.TaxID = in.TaxID
+		client.BillingEmail = in.BillingEmail
+		client.BillingAddress = in.BillingAddress
+		client.Currency = in.Currency
+		client.Notes = in.Notes
+		client.Active = in.Active
+		if err := tx.Save(&client).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("client_id = ?", id).Delete(&ClientContact{}).Error; err != nil {
+			return err
+		}
+		client.Contacts = clientContacts(in.Contacts)
+		for i := range client.Contacts {
+			client.Contacts[i].ClientID = id
+		}
+		if len(client.Contacts) > 0 {
+			return tx.Create(&client.Contacts).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (s *ClientService) GetClient(ctx context.Context, id uint) (*Client, error) {
+	var client Client
+	if err := s.db.WithContext(ctx).Preload("Contacts").First(&client, id).Error; err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (s *ClientService) ListClients(ctx context.Context, filter ClientFilter) (ClientsPage, error) {
+	filter = sanitizeClientFilter(filter)
+	tx := s.db.WithContext(ctx).Model(&Client{})
+	if filter.Search != "" {
+		tx = tx.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(filter.Search)+"%")
+	}
+	if filter.Active != nil {
+		tx = tx.Where("active = ?", *filter.Active)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return ClientsPage{}, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	var clients []Client
+	if err := tx.Preload("Contacts").
+		Order("name").
+		Limit(filter.PageSize).
+		Offset(offset).
+		Find(&clients).Error; err != nil {
+		return ClientsPage{}, err
+	}
+	return ClientsPage{Items: clients, Total: total}, nil
+}
+
+// DeleteClient removes a client without projects.
+func (s *ClientService) DeleteClient(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&Client{}, id).Error; err != nil {
+			return err
+		}
+		var projects int64
+		if err := tx.Model(&Project{}).Where("client_id = ?", id).Count(&projects).Error; err != nil {
+			return err
+		}
+		if projects > 0 {
+			return ErrClientHasProjects
+		}
+		if err := tx.Where("client_id = ?", id).Delete(&ClientContact{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Client{}, id).Error
+	})
+}
+
+// Summary rolls up the projects of the client, archived ones included, and
+// their approved and pending hours.
+func (s *ClientService) Summary(ctx context.Context, id uint) (*ClientSummary, error) {
+	db := s.db.WithContext(ctx)
+
+	var client Client
+	if err := db.First(&client, id).Error; err != nil {
+		return nil, err
+	}
+	var projects []Project
+	if err := db.Where("client_id = ?", id).Order("name, id").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ProjectID uint
+		Status    TimeEntryStatus
+		Hours     float64
+	}
+	if err := db.Model(&TimeEntry{}).
+		Select("tasks.project_id, time_entries.status, COALESCE(SUM(time_entries.hours), 0) AS hours").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+		Joins("JOIN projects ON projects.id = tasks.project_id").
+		Where("projects.client_id = ? AND time_entries.status <> ?", id, TimeEntryRejected).
+		Group("tasks.project_id, time_entries.status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	summary := &ClientSummary{
+		ClientID:         client.ID,
+		Name:             client.Name,
+		Projects:         len(projects),
+		ProjectsByStatus: map[ProjectStatus]int{},
+		ProjectSummaries: make([]ClientProjectSummary, len(projects)),
+	}
+	byProject := make(map[uint]*ClientProjectSummary, len(projects))
+	for i, project := range projects {
+		summary.ProjectsByStatus[project.Status]++
+		summary.ProjectSummaries[i] = ClientProjectSummary{
+			ProjectID: project.ID,
+			Name:      project.Name,
+			Status:    project.Status,
+			Archived:  project.ArchivedAt != nil,
+		}
+		byProject[project.ID] = &summary.ProjectSummaries[i]
+	}
+	for _, row := range rows {
+		item := byProject[row.ProjectID]
+		if item == nil {
+			continue
+		}
+		if row.Status == TimeEntryApproved {
+			item.ApprovedHours = roundHours(item.ApprovedHours + row.Hours)
+			summary.ApprovedHours += row.Hours
+		} else {
+			item.PendingHours = roundHours(item.PendingHours + row.Hours)
+			summary.PendingHours += row.Hours
+		}
+	}
+	summary.ApprovedHours = roundHours(summary.ApprovedHours)
+	summary.PendingHours = roundHours(summary.PendingHours)
+	summary.TotalHours = roundHours(summary.ApprovedHours + summary.PendingHours)
+	return summary, nil
+}
+
+// resolveClient returns the client a project belongs to: the given ID, or
+// the existing client matching name (ignoring case and surrounding spaces).
+// Unknown names are never turned into new clients; the error lists the
+// closest existing names instead. Inactive clients cannot receive projects.
+func resolveClient(tx *gorm.DB, clientID *uint, name string) (*Client, error) {
+	var client Client
+	if clientID != nil {
+		err := tx.First(&client, *clientID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("client %d not found", *clientID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		name = strings.TrimSpace(name)
+		err := tx.Where("LOWER(name) = ?", strings.ToLower(name)).First(&client).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			similar, err := similarClientNames(tx, name)
+			if err != nil {
+				return nil, err
+			}
+			if len(similar) == 0 {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownClient, name)
+			}
+			return nil, fmt.Errorf("%w: %q, did you mean %s", ErrUnknownClient, name, strings.Join(similar, ", "))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !client.Active {
+		return nil, fmt.Errorf("%w: %s", ErrClientInactive, client.Name)
+	}
+	return &client, nil
+}
+
+// similarClientNames returns up to five active clients whose name contains a
+// word of name, or is contained in it, ignoring case.
+func similarClientNames(tx *gorm.DB, name string) ([]string, error) {
+	lower := strings.ToLower(name)
+	conds := []string{"? LIKE '%' || LOWER(name) || '%'"}
+	args := []interface{}{lower}
+	for _, word := range strings.Fields(lower) {
+		// palavras curtas demais casariam com quase todo cliente
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		conds = append(conds, "LOWER(name) LIKE ?")
+		args = append(args, "%"+word+"%")
+	}
+	var names []string
+	err := tx.Model(&Client{}).
+		Where("active = ?", true).
+		Where(strings.Join(conds, " OR "), args...).
+		Order("name").Limit(5).
+		Pluck("name", &names).Error
+	return names, err
+}
+
+func ensureClientNameFree(tx *gorm.DB, name string, exceptID uint) error {
+	var count int64
+	q := tx.Model(&Client{}).Where("LOWER(name) = ?", strings.ToLower(name))
+	if exceptID != 0 {
+		q = q.Where("id <> ?", exceptID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrClientNameTaken
+	}
+	return nil
+}
+
+func normalizeClientInput(in ClientInput) (ClientInput, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		return in, errors.New("client name is required")
+	}
+	in.Currency = strings.ToUpper(strings.TrimSpace(in.Currency))
+	if in.Currency == "" {
+		in.Currency = "BRL"
+	}
+	if len(in.Currency) != 3 {
+		return in, errors.New("currency must be a 3-letter ISO code")
+	}
+	primary := 0
+	for i, contact := range in.Contacts {
+		in.Contacts[i].Name = strings.TrimSpace(contact.Name)
+		if in.Contacts[i].Name == "" {
+			return in, errors.New("contact name is required")
+		}
+		if contact.IsPrimary {
+			primary++
+		}
+	}
+	if primary > 1 {
+		return in, errors.New("only one contact can be primary")
+	}
+	return in, nil
+}
+
+func clientContacts(in []ClientContactInput) []ClientContact {
+	contacts := make([]ClientContact, len(in))
+	for i, c := range in {
+		contacts[i] = ClientContact{
+			Name:      c.Name,
+			Email:     strings.TrimSpace(c.Email),
+			Phone:     strings.TrimSpace(c.Phone),
+			Role:      strings.TrimSpace(c.Role),
+			IsPrimary: c.IsPrimary,
+		}
+	}
+	return contacts
+}
+
+func sanitizeClientFilter(filter ClientFilter) ClientFilter {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	return filter
+}
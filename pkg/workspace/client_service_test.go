@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientService_ResolveRenameAndSummary(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	clientSvc := NewClientService(db)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	portal, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	app, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "App", ClientName: " acme ", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if portal.ClientID == nil || app.ClientID == nil || *portal.ClientID != *app.ClientID {
+		t.Fatalf("expected both projects on the same client, got %v and %v", portal.ClientID, app.ClientID)
+	}
+	clientID := *portal.ClientID
+
+	if _, err := clientSvc.CreateClient(ctx, ClientInput{Name: "Acme"}); !errors.Is(err, ErrClientNameTaken) {
+		t.Fatalf("expected ErrClientNameTaken, got %v", err)
+	}
+	if _, err := clientSvc.UpdateClient(ctx, clientID, ClientInput{Name: "ACME", Contacts: []ClientContactInput{
+		{Name: "Ana", IsPrimary: true},
+		{Name: "Bruno", IsPrimary: true},
+	}}); err == nil {
+		t.Fatal("expected two primary contacts to be rejected")
+	}
+
+	client, err := clientSvc.UpdateClient(ctx, clientID, ClientInput{
+		Name:     "ACME Corp",
+		Active:   true,
+		Contacts: []ClientContactInput{{Name: "Ana", Email: "ana@acme.test", IsPrimary: true}},
+	})
+	if err != nil {
+		t.Fatalf("update client: %v", err)
+	}
+	if len(client.Contacts) != 1 || !client.Contacts[0].IsPrimary {
+		t.Fatalf("unexpected contacts: %+v", client.Contacts)
+	}
+	renamed, err := projectSvc.GetProject(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("get project: %v", err)
+	}
+	if renamed.ClientName != "ACME Corp" {
+		t.Fatalf("expected project client name to follow the rename, got %q", renamed.ClientName)
+	}
+
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	approved, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 4})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, approved.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2), Hours: 2.5}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	summary, err := clientSvc.Summary(ctx, clientID)
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if summary.Projects != 2 || summary.ProjectsByStatus[ProjectPlanning] != 2 {
+		t.Fatalf("unexpected project counts: %+v", summary)
+	}
+	if summary.ApprovedHours != 4 || summary.PendingHours != 2.5 || summary.TotalHours != 6.5 {
+		t.Fatalf("unexpected hours: %+v", summary)
+	}
+
+	if err := clientSvc.DeleteClient(ctx, clientID); !errors.Is(err, ErrClientHasProjects) {
+		t.Fatalf("expected ErrClientHasProjects, got %v", err)
+	}
+
+	if _, err := clientSvc.UpdateClient(ctx, clientID, ClientInput{Name: "ACME Corp", Active: false}); err != nil {
+		t.Fatalf("deactivate client: %v", err)
+	}
+	if _, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Intranet", ClientID: &clientID, StartDate: start, OwnerID: 1}); !errors.Is(err, ErrClientInactive) {
+		t.Fatalf("expected ErrClientInactive, got %v", err)
+	}
+
+	page, err := clientSvc.ListClients(ctx, ClientFilter{Search: "acme"})
+	if err != nil {
+		t.Fatalf("list clients: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].ID != clientID {
+		t.Fatalf("unexpected clients page: %+v", page)
+	}
+}
+
+func TestProjectService_UnknownClientIsNotCreated(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	ctx := context.Background()
+
+	var before int64
+	if err := db.Model(&Client{}).Count(&before).Error; err != nil {
+		t.Fatalf("count clients: %v", err)
+	}
+	start := truncateDay(time.Now().UTC())
+	_, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "Acme Ltda", StartDate: start, OwnerID: 1})
+	if !errors.Is(err, ErrUnknownClient) {
+		t.Fatalf("expected ErrUnknownClient, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ACME") {
+		t.Fatalf("expected ACME among the suggestions, got %v", err)
+	}
+	if _, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "Initech", StartDate: start, OwnerID: 1}); !errors.Is(err, ErrUnknownClient) {
+		t.Fatalf("expected ErrUnknownClient, got %v", err)
+	}
+
+	var after int64
+	if err := db.Model(&Client{}).Count(&after).Error; err != nil {
+		t.Fatalf("count clients: %v", err)
+	}
+	if after != before {
+		t.Fatalf("expected no client to be created, got %d clients instead of %d", after, before)
+	}
+}
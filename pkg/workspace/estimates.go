@@ -0,0 +1,275 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// TaskEstimate compares the estimates of a task with the hours logged on it,
+// rejected entries excluded. RemainingHours is the remaining estimate set on
+// the task or, when unset, what is left of the original one; done tasks have
+// nothing remaining. VarianceHours is ProjectedHours minus OriginalHours and
+// is only computed for tasks with an estimate.
+type TaskEstimate struct {
+	TaskID          uint
+	ProjectID       uint
+	AssigneeID      uint
+	Title           string
+	Status          TaskStatus
+	OriginalHours   float64
+	RemainingHours  float64
+	LoggedHours     float64
+	ProjectedHours  float64
+	VarianceHours   float64
+	VariancePercent float64
+	OverEstimate    bool
+}
+
+// EstimateVarianceGroup sums the task estimates of a project, an assignee or
+// the whole report. Only the key of the grouping is set. Hours and variance
+// cover the tasks with an estimate; hours logged on the others are reported
+// as UnestimatedHours.
+type EstimateVarianceGroup struct {
+	ProjectID         *uint
+	AssigneeID        *uint
+	Tasks             int
+	EstimatedTasks    int
+	DoneTasks         int
+	OverEstimateTasks int
+	OriginalHours     float64
+	RemainingHours    float64
+	LoggedHours       float64
+	ProjectedHours    float64
+	VarianceHours     float64
+	VariancePercent   float64
+	UnestimatedHours  float64
+}
+
+// EstimateVarianceFilter selects the tasks of the variance report. Status
+// narrows it down, e.g. to done tasks to measure finished work only.
+type EstimateVarianceFilter struct {
+	ProjectID  *uint
+	AssigneeID *uint
+	Status     []TaskStatus
+	// VisibleTo restricts the report to the tasks the user can list. Nil
+	// means no restriction.
+	VisibleTo *uint
+}
+
+// EstimateVarianceReport compares estimated and actual hours per project and
+// per assignee.
+type EstimateVarianceReport struct {
+	Totals     EstimateVarianceGroup
+	ByProject  []EstimateVarianceGroup
+	ByAssignee []EstimateVarianceGroup
+	Tasks      []TaskEstimate
+}
+
+// WithEstimateWarningFactor makes LogTime and UpdateEntry warn when the hours
+// of a task go over its estimate times factor. Zero disables the warning.
+func (s *TimeEntryService) WithEstimateWarningFactor(factor float64) *TimeEntryService {
+	s.estimateWarningFactor = factor
+	return s
+}
+
+// Estimate compares the estimates of a task with the hours logged on it.
+func (s *TaskService) Estimate(ctx context.Context, id uint) (*TaskEstimate, error) {
+	db := s.db.WithContext(ctx)
+	var task Task
+	if err := db.First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	hours, err := loggedHoursByTask(db, []uint{task.ID})
+	if err != nil {
+		return nil, err
+	}
+	estimate := newTaskEstimate(task, hours[task.ID])
+	return &estimate, nil
+}
+
+// EstimateVariance builds the variance report from one query for the tasks
+// and one for their hours.
+func (s *ReportService) EstimateVariance(ctx context.Context, filter EstimateVarianceFilter) (*EstimateVarianceReport, error) {
+	for _, status := range filter.Status {
+		switch status {
+		case TaskTodo, TaskInProgress, TaskBlocked, TaskDone:
+		default:
+			return nil, fmt.Errorf("%w: invalid task status %q", ErrInvalidReportFilter, status)
+		}
+	}
+	taskFilter := TaskFilter{Status: filter.Status, AssigneeID: filter.AssigneeID, VisibleTo: filter.VisibleTo}
+	if filter.ProjectID != nil {
+		if *filter.ProjectID == 0 {
+			return nil, fmt.Errorf("%w: invalid project id", ErrInvalidReportFilter)
+		}
+		taskFilter.ProjectID = *filter.ProjectID
+	}
+
+	db := s.db.WithContext(ctx)
+	var tasks []Task
+	if err := applyTaskFilter(db.Model(&Task{}), taskFilter).
+		Order("project_id, id").
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	hours, err := loggedHoursByTask(db, applyTaskFilter(db.Session(&gorm.Session{NewDB: true}).
+		Model(&Task{}), taskFilter).Select("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EstimateVarianceReport{
+		ByProject:  []EstimateVarianceGroup{},
+		ByAssignee: []EstimateVarianceGroup{},
+		Tasks:      make([]TaskEstimate, 0, len(tasks)),
+	}
+	byProject := map[uint]*EstimateVarianceGroup{}
+	byAssignee := map[uint]*EstimateVarianceGroup{}
+	for _, task := range tasks {
+		estimate := newTaskEstimate(task, hours[task.ID])
+		report.Tasks = append(report.Tasks, estimate)
+
+		project, ok := byProject[task.ProjectID]
+		if !ok {
+			id := task.ProjectID
+			project = &EstimateVarianceGroup{ProjectID: &id}
+			byProject[id] = project
+		}
+		assignee, ok := byAssignee[task.AssigneeID]
+		if !ok {
+			id := task.AssigneeID
+			assignee = &EstimateVarianceGroup{AssigneeID: &id}
+			byAssignee[id] = assignee
+		}
+		for _, group := range []*EstimateVarianceGroup{&report.Totals, project, assignee} {
+			group.add(estimate)
+		}
+	}
+
+	report.Totals.round()
+	for _, group := range byProject {
+		group.round()
+		report.ByProject = append(report.ByProject, *group)
+	}
+	for _, group := range byAssignee {
+		group.round()
+		report.ByAssignee = append(report.ByAssignee, *group)
+	}
+	sort.Slice(report.ByProject, func(i, j int) bool {
+		return *report.ByProject[i].ProjectID < *report.ByProject[j].ProjectID
+	})
+	sort.Slice(report.ByAssignee, func(i, j int) bool {
+		return *report.ByAssignee[i].AssigneeID < *report.ByAssignee[j].AssigneeID
+	})
+	return report, nil
+}
+
+func (g *EstimateVarianceGroup) add(estimate TaskEstimate) {
+	g.Tasks++
+	if estimate.Status == TaskDone {
+		g.DoneTasks++
+	}
+	if estimate.OriginalHours <= 0 {
+		g.UnestimatedHours += estimate.LoggedHours
+		return
+	}
+	g.EstimatedTasks++
+	if estimate.OverEstimate {
+		g.OverEstimateTasks++
+	}
+	g.OriginalHours += estimate.OriginalHours
+	g.RemainingHours += estimate.RemainingHours
+	g.LoggedHours += estimate.LoggedHours
+	g.ProjectedHours += estimate.ProjectedHours
+}
+
+func (g *EstimateVarianceGroup) round() {
+	g.OriginalHours = roundHours(g.OriginalHours)
+	g.RemainingHours = roundHours(g.RemainingHours)
+	g.LoggedHours = roundHours(g.LoggedHours)
+	g.ProjectedHours = roundHours(g.ProjectedHours)
+	g.UnestimatedHours = roundHours(g.UnestimatedHours)
+	if g.OriginalHours > 0 {
+		g.VarianceHours = roundHours(g.ProjectedHours - g.OriginalHours)
+		g.VariancePercent = percentOf(g.VarianceHours, g.OriginalHours)
+	}
+}
+
+func newTaskEstimate(task Task, logged float64) TaskEstimate {
+	estimate := TaskEstimate{
+		TaskID:        task.ID,
+		ProjectID:     task.ProjectID,
+		AssigneeID:    task.AssigneeID,
+		Title:         task.Title,
+		Status:        task.Status,
+		OriginalHours: task.EstimateHours,
+		LoggedHours:   roundHours(logged),
+	}
+	switch {
+	case task.Status == TaskDone:
+	case task.RemainingEstimateHours != nil:
+		estimate.RemainingHours = *task.RemainingEstimateHours
+	default:
+		estimate.RemainingHours = roundHours(math.Max(task.EstimateHours-estimate.LoggedHours, 0))
+	}
+	estimate.ProjectedHours = roundHours(estimate.LoggedHours + estimate.RemainingHours)
+	if estimate.OriginalHours > 0 {
+		estimate.VarianceHours = roundHours(estimate.ProjectedHours - estimate.OriginalHours)
+		estimate.VariancePercent = percentOf(estimate.VarianceHours, estimate.OriginalHours)
+		estimate.OverEstimate = estimate.LoggedHours > estimate.OriginalHours
+	}
+	return estimate
+}
+
+// loggedHoursByTask sums the non-rejected hours of the given tasks; taskIDs is
+// a slice of IDs or a subquery selecting them.
+func loggedHoursByTask(db *gorm.DB, taskIDs any) (map[uint]float64, error) {
+	var rows []struct {
+		TaskID uint
+		Hours  float64
+	}
+	if err := db.Model(&TimeEntry{}).
+		Select("task_id, COALESCE(SUM(hours), 0) AS hours").
+		Where("task_id IN (?)", taskIDs).
+		Where("status <> ?", TimeEntryRejected).
+		Group("task_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	hours := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		hours[row.TaskID] = row.Hours
+	}
+	return hours, nil
+}
+
+// checkEstimate warns when the non-rejected hours of the task, with this
+// entry, go over its estimate times the configured factor.
+func (s *TimeEntryService) checkEstimate(ctx context.Context, task *Task, hours float64, excludeID uint) ([]string, error) {
+	if s.estimateWarningFactor <= 0 || task.EstimateHours <= 0 {
+		return nil, nil
+	}
+
+	var used float64
+	tx := s.db.WithContext(ctx).Model(&TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("task_id = ?", task.ID).
+		Where("status <> ?", TimeEntryRejected)
+	if excludeID != 0 {
+		tx = tx.Where("id <> ?", excludeID)
+	}
+	if err := tx.Scan(&used).Error; err != nil {
+		return nil, err
+	}
+
+	total := roundHours(used + hours)
+	if total <= roundHours(task.EstimateHours*s.estimateWarningFactor) {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf("task estimate exceeded: %.2f hours logged against %.2f estimated (warning factor %.2f)",
+		total, task.EstimateHours, s.estimateWarningFactor)}, nil
+}
@@ -0,0 +1,133 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimates_VarianceReportAndWarnings(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db).WithEstimateWarningFactor(1.2)
+	reportSvc := NewReportService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	portal, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	support, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Suporte", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	login, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Login", AssigneeID: 7, EstimateHours: 10})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	signup, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Cadastro", AssigneeID: 8, EstimateHours: 5})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	ticket, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: support.ID, Title: "Chamado", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	negative := -1.0
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Perfil", AssigneeID: 7, RemainingEstimateHours: &negative}); err == nil {
+		t.Fatal("expected negative remaining estimate to be rejected")
+	}
+
+	for i, hours := range []float64{4, 5} {
+		entry, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: login.ID, UserID: 7, EntryDate: start.AddDate(0, 0, i+1), Hours: hours})
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+		if len(entry.Warnings) != 0 {
+			t.Fatalf("expected no warnings within the estimate factor, got %v", entry.Warnings)
+		}
+	}
+	over, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: login.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 3), Hours: 4})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if len(over.Warnings) != 1 || !strings.HasPrefix(over.Warnings[0], "task estimate exceeded: 13.00 hours logged against 10.00 estimated") {
+		t.Fatalf("expected an estimate warning, got %v", over.Warnings)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, over.ID, 1, "horas a mais"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: signup.ID, UserID: 8, EntryDate: start.AddDate(0, 0, 1), Hours: 4}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: ticket.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 4), Hours: 2}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	estimate, err := taskSvc.Estimate(ctx, login.ID)
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if estimate.LoggedHours != 9 || estimate.RemainingHours != 1 || estimate.VarianceHours != 0 {
+		t.Fatalf("expected the remaining work to be derived from rejected-free hours, got %+v", estimate)
+	}
+	remaining := 3.0
+	if _, err := taskSvc.UpdateTask(ctx, login.ID, TaskUpdateInput{Title: login.Title, Status: TaskInProgress, AssigneeID: 7, EstimateHours: 10, RemainingEstimateHours: &remaining}); err != nil {
+		t.Fatalf("update remaining estimate: %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, signup.ID, TaskUpdateInput{Title: signup.Title, Status: TaskDone, AssigneeID: 8, EstimateHours: 5, RemainingEstimateHours: &remaining}); err != nil {
+		t.Fatalf("finish task: %v", err)
+	}
+	estimate, err = taskSvc.Estimate(ctx, signup.ID)
+	if err != nil {
+		t.Fatalf("estimate: %v", err)
+	}
+	if estimate.RemainingHours != 0 || estimate.ProjectedHours != 4 || estimate.VarianceHours != -1 || estimate.VariancePercent != -20 {
+		t.Fatalf("expected a done task to keep only its logged hours, got %+v", estimate)
+	}
+
+	report, err := reportSvc.EstimateVariance(ctx, EstimateVarianceFilter{})
+	if err != nil {
+		t.Fatalf("variance report: %v", err)
+	}
+	totals := report.Totals
+	if totals.Tasks != 3 || totals.EstimatedTasks != 2 || totals.DoneTasks != 1 || totals.OriginalHours != 15 ||
+		totals.LoggedHours != 13 || totals.ProjectedHours != 16 || totals.VarianceHours != 1 ||
+		totals.VariancePercent != 6.67 || totals.UnestimatedHours != 2 {
+		t.Fatalf("unexpected totals %+v", totals)
+	}
+	if len(report.ByProject) != 2 || *report.ByProject[0].ProjectID != portal.ID || report.ByProject[1].EstimatedTasks != 0 {
+		t.Fatalf("unexpected project groups %+v", report.ByProject)
+	}
+	if len(report.ByAssignee) != 2 || *report.ByAssignee[0].AssigneeID != 7 || report.ByAssignee[0].VarianceHours != 2 ||
+		report.ByAssignee[0].UnestimatedHours != 2 || report.ByAssignee[1].VarianceHours != -1 {
+		t.Fatalf("unexpected assignee groups %+v", report.ByAssignee)
+	}
+
+	done, err := reportSvc.EstimateVariance(ctx, EstimateVarianceFilter{ProjectID: &portal.ID, Status: []TaskStatus{TaskDone}})
+	if err != nil {
+		t.Fatalf("variance report: %v", err)
+	}
+	if len(done.Tasks) != 1 || done.Tasks[0].TaskID != signup.ID {
+		t.Fatalf("expected only the done task, got %+v", done.Tasks)
+	}
+	assignee := uint(8)
+	visible, err := reportSvc.EstimateVariance(ctx, EstimateVarianceFilter{VisibleTo: &assignee})
+	if err != nil {
+		t.Fatalf("variance report: %v", err)
+	}
+	if len(visible.Tasks) != 1 || visible.Tasks[0].TaskID != signup.ID {
+		t.Fatalf("expected the report to be scoped to visible tasks, got %+v", visible.Tasks)
+	}
+	if _, err := reportSvc.EstimateVariance(ctx, EstimateVarianceFilter{Status: []TaskStatus{"late"}}); !errors.Is(err, ErrInvalidReportFilter) {
+		t.Fatalf("expected an invalid status to be rejected, got %v", err)
+	}
+	zero := uint(0)
+	if _, err := reportSvc.EstimateVariance(ctx, EstimateVarianceFilter{ProjectID: &zero}); !errors.Is(err, ErrInvalidReportFilter) {
+		t.Fatalf("expected an invalid project id to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrPolicyViolation is returned when an entry breaks a hard working-hour rule.
+var ErrPolicyViolation = errors.New("working-hour policy violation")
+
+// WithDefaultPolicy sets the policy applied to users without an override.
+// Callers validate it first with ValidateHourPolicy.
+func (s *TimeEntryService) WithDefaultPolicy(policy HourPolicy) *TimeEntryService {
+	policy.ID = 0
+	policy.UserID = 0
+	s.defaultPolicy = policy
+	return s
+}
+
+// EffectivePolicy returns the user's override or the default policy.
+func (s *TimeEntryService) EffectivePolicy(ctx context.Context, userID uint) (HourPolicy, error) {
+	var policy HourPolicy
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		policy = s.defaultPolicy
+		policy.UserID = userID
+		return policy, nil
+	}
+	if err != nil {
+		return HourPolicy{}, err
+	}
+	return policy, nil
+}
+
+// SetUserPolicy creates or replaces the override of a user.
+func (s *TimeEntryService) SetUserPolicy(ctx context.Context, userID uint, in HourPolicy) (*HourPolicy, error) {
+	if userID == 0 {
+		return nil, errors.New("user is required")
+	}
+	if err := ValidateHourPolicy(in); err != nil {
+		return nil, err
+	}
+
+	var policy HourPolicy
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&policy).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	policy.UserID = userID
+	policy.MaxDailyHours = in.MaxDailyHours
+	policy.MaxWeeklyHours = in.MaxWeeklyHours
+	policy.DailyOvertimeHours = in.DailyOvertimeHours
+	policy.WeeklyOvertimeHours = in.WeeklyOvertimeHours
+	policy.WeekendRule = in.WeekendRule
+	if err := s.db.WithContext(ctx).Save(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// ResetUserPolicy removes the override so the default policy applies again.
+func (s *TimeEntryService) ResetUserPolicy(ctx context.Context, userID uint) error {
+	return s.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&HourPolicy{}).Error
+}
+
+// checkHourPolicy sums the user's other entries on the same day and ISO week
+// and evaluates the effective policy against the new value.
+func (s *TimeEntryService) checkHourPolicy(ctx context.Context, userID uint, date time.Time, hours float64, excludeID uint) ([]string, error) {
+	policy, err := s.EffectivePolicy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := truncateDay(date)
+	year, week := date.UTC().ISOWeek()
+	weekStart, err := isoWeekStart(year, week)
+	if err != nil {
+		return nil, err
+	}
+
+	dayTotal, err := s.sumUserHours(ctx, userID, day, day.AddDate(0, 0, 1), excludeID)
+	if err != nil {
+		return nil, err
+	}
+	weekTotal, err := s.sumUserHours(ctx, userID, weekStart, weekStart.AddDate(0, 0, 7), excludeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateHourPolicy(policy, date, hours, dayTotal, weekTotal)
+}
+
+func (s *TimeEntryService) sumUserHours(ctx context.Context, userID uint, from, until time.Time, excludeID uint) (float64, error) {
+	var total float64
+	tx := s.db.WithContext(ctx).Model(&TimeEntry{}).
+		Select("COALESCE(SUM(hours), 0)").
+		Where("user_id = ? AND entry_date >= ? AND entry_date < ? AND status <> ?", userID, from, until, TimeEntryRejected)
+	if excludeID != 0 {
+		tx = tx.Where("id <> ?", excludeID)
+	}
+	if err := tx.Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func evaluateHourPolicy(policy HourPolicy, date time.Time, hours, dayTotal, weekTotal float64) ([]string, error) {
+	newDay := roundHours(dayTotal + hours)
+	newWeek := roundHours(weekTotal + hours)
+
+	if policy.MaxDailyHours > 0 && newDay > policy.MaxDailyHours {
+		return nil, fmt.Errorf("%w: %.2f hours on %s exceed the daily limit of %.2f",
+			ErrPolicyViolation, newDay, date.UTC().Format("2006-01-02"), policy.MaxDailyHours)
+	}
+	if policy.MaxWeeklyHours > 0 && newWeek > policy.MaxWeeklyHours {
+		return nil, fmt.Errorf("%w: %.2f hours in the week exceed the weekly limit of %.2f",
+			ErrPolicyViolation, newWeek, policy.MaxWeeklyHours)
+	}
+
+	var warnings []string
+	weekday := date.UTC().Weekday()
+	if weekday == time.Saturday || weekday == time.Sunday {
+		switch policy.WeekendRule {
+		case WeekendBlock:
+			return nil, fmt.Errorf("%w: entries on weekends are not allowed", ErrPolicyViolation)
+		case WeekendWarn:
+			warnings = append(warnings, "entry logged on a weekend")
+		}
+	}
+	if policy.DailyOvertimeHours > 0 && newDay > policy.DailyOvertimeHours {
+		warnings = append(warnings, fmt.Sprintf("daily overtime: %.2f hours above %.2f",
+			roundHours(newDay-policy.DailyOvertimeHours), policy.DailyOvertimeHours))
+	}
+	if policy.WeeklyOvertimeHours > 0 && newWeek > policy.WeeklyOvertimeHours {
+		warnings = append(warnings, fmt.Sprintf("weekly overtime: %.2f hours above %.2f",
+			roundHours(newWeek-policy.WeeklyOvertimeHours), policy.WeeklyOvertimeHours))
+	}
+	return warnings, nil
+}
+
+// ValidateHourPolicy checks the limits and the weekend rule of a policy. The
+// default policy built from the environment goes through it at startup.
+func ValidateHourPolicy(policy HourPolicy) error {
+	for _, v := range []float64{policy.MaxDailyHours, policy.MaxWeeklyHours, policy.DailyOvertimeHours, policy.WeeklyOvertimeHours} {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return errors.New("policy hours must be zero or positive numbers")
+		}
+	}
+	if policy.MaxDailyHours > 24 {
+		return errors.New("daily limit cannot exceed 24 hours")
+	}
+	if policy.MaxWeeklyHours > 168 {
+		return errors.New("weekly limit cannot exceed 168 hours")
+	}
+	switch policy.WeekendRule {
+	case WeekendAllow, WeekendWarn, WeekendBlock:
+	default:
+		return fmt.Errorf("invalid weekend rule %q", policy.WeekendRule)
+	}
+	return nil
+}
+
+func roundHours(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,255 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MilestoneInput holds milestone data. An empty Status means planned.
+type MilestoneInput struct {
+	Name        string
+	Description string
+	TargetDate  time.Time
+	Status      MilestoneStatus
+}
+
+// MilestoneProgress counts the tasks attached to a milestone by status.
+type MilestoneProgress struct {
+	TotalTasks    int
+	DoneTasks     int
+	TasksByStatus map[TaskStatus]int
+	PercentDone   float64
+}
+
+// MilestoneWithProgress is a milestone with the progress of its tasks.
+type MilestoneWithProgress struct {
+	Milestone
+	Progress MilestoneProgress
+}
+
+// CreateMilestone adds a milestone to a project; the target date must fall
+// within the project dates.
+func (s *ProjectService) CreateMilestone(ctx context.Context, projectID uint, in MilestoneInput) (*Milestone, error) {
+	in, err := normalizeMilestoneInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := s.db.WithContext(ctx).First(&project, projectID).Error; err != nil {
+		return nil, err
+	}
+	if project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
+	if err := validateMilestoneTarget(in.TargetDate, &project); err != nil {
+		return nil, err
+	}
+
+	milestone := &Milestone{
+		ProjectID:   project.ID,
+		Name:        in.Name,
+		Description: in.Description,
+		TargetDate:  in.TargetDate,
+		Status:      in.Status,
+	}
+	if err := s.db.WithContext(ctx).Create(milestone).Error; err != nil {
+		return nil, err
+	}
+	return milestone, nil
+}
+
+// UpdateMilestone replaces the milestone data. The target date cannot move
+// before the due date of an attached task.
+func (s *ProjectService) UpdateMilestone(ctx context.Context, id uint, in MilestoneInput) (*Milestone, error) {
+	in, err := normalizeMilestoneInput(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var milestone Milestone
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&milestone, id).Error; err != nil {
+			return err
+		}
+		var project Project
+		if err := tx.First(&project, milestone.ProjectID).Error; err != nil {
+			return err
+		}
+		if project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if err := validateMilestoneTarget(in.TargetDate, &project); err != nil {
+			return err
+		}
+
+		var late int64
+		if err := tx.Model(&Task{}).
+			Where("milestone_id = ? AND due_date >= ?", id, truncateDay(in.TargetDate).AddDate(0, 0, 1)).
+			Count(&late).Error; err != nil {
+			return err
+		}
+		if late > 0 {
+			return fmt.Errorf("%d task(s) are due after the new target date", late)
+		}
+
+		milestone.Name = in.Name
+		milestone.Description = in.Description
+		milestone.TargetDate = in.TargetDate
+		milestone.Status = in.Status
+		return tx.Save(&milestone).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+func (s *ProjectService) GetMilestone(ctx context.Context, id uint) (*MilestoneWithProgress, error) {
+	var milestone Milestone
+	if err := s.db.WithContext(ctx).First(&milestone, id).Error; err != nil {
+		return nil, err
+	}
+	items, err := s.withProgress(ctx, []Milestone{milestone})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// ListMilestones returns the milestones of a project by target date.
+func (s *ProjectService) ListMilestones(ctx context.Context, projectID uint) ([]MilestoneWithProgress, error) {
+	var milestones []Milestone
+	if err := s.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("target_date, id").
+		Find(&milestones).Error; err != nil {
+		return nil, err
+	}
+	return s.withProgress(ctx, milestones)
+}
+
+// DeleteMilestone removes a milestone; its tasks stay in the project.
+func (s *ProjectService) DeleteMilestone(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var milestone Milestone
+		if err := tx.First(&milestone, id).Error; err != nil {
+			return err
+		}
+		var project Project
+		if err := tx.First(&project, milestone.ProjectID).Error; err != nil {
+			return err
+		}
+		if project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if err := tx.Model(&Task{}).
+			Where("milestone_id = ?", id).
+			Update("milestone_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Milestone{}, id).Error
+	})
+}
+
+func (s *ProjectService) withProgress(ctx context.Context, milestones []Milestone) ([]MilestoneWithProgress, error) {
+	items := make([]MilestoneWithProgress, len(milestones))
+	if len(milestones) == 0 {
+		return items, nil
+	}
+	ids := make([]uint, len(milestones))
+	byID := make(map[uint]*MilestoneProgress, len(milestones))
+	for i, milestone := range milestones {
+		ids[i] = milestone.ID
+		items[i] = MilestoneWithProgress{
+			Milestone: milestone,
+			Progress:  MilestoneProgress{TasksByStatus: map[TaskStatus]int{}},
+		}
+		byID[milestone.ID] = &items[i].Progress
+	}
+
+	var rows []struct {
+		MilestoneID uint
+		Status      TaskStatus
+		Tasks       int
+	}
+	if err := s.db.WithContext(ctx).Model(&Task{}).
+		Select("milestone_id, status, COUNT(*) AS tasks").
+		Where("milestone_id IN ?", ids).
+		Group("milestone_id, status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		progress := byID[row.MilestoneID]
+		progress.TasksByStatus[row.Status] = row.Tasks
+		progress.TotalTasks += row.Tasks
+		if row.Status == TaskDone {
+			progress.DoneTasks += row.Tasks
+		}
+	}
+	for _, progress := range byID {
+		if progress.TotalTasks > 0 {
+			progress.PercentDone = math.Round(float64(progress.DoneTasks)/float64(progress.TotalTasks)*10000) / 100
+		}
+	}
+	return items, nil
+}
+
+// validateTaskMilestone checks that the milestone belongs to the project,
+// still accepts tasks and is not due before the task.
+func validateTaskMilestone(tx *gorm.DB, milestoneID *uint, projectID uint, due *time.Time) error {
+	if milestoneID == nil {
+		return nil
+	}
+	var milestone Milestone
+	err := tx.First(&milestone, *milestoneID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && milestone.ProjectID != projectID) {
+		return fmt.Errorf("milestone %d not found in project", *milestoneID)
+	}
+	if err != nil {
+		return err
+	}
+	if milestone.Status == MilestoneCanceled {
+		return errors.New("cannot attach tasks to a canceled milestone")
+	}
+	if due != nil && truncateDay(*due).After(truncateDay(milestone.TargetDate)) {
+		return errors.New("due date cannot be after milestone target date")
+	}
+	return nil
+}
+
+func normalizeMilestoneInput(in MilestoneInput) (MilestoneInput, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		return in, errors.New("milestone name is required")
+	}
+	if in.TargetDate.IsZero() {
+		return in, errors.New("target date is required")
+	}
+	if in.Status == "" {
+		in.Status = MilestonePlanned
+	}
+	switch in.Status {
+	case MilestonePlanned, MilestoneInProgress, MilestoneCompleted, MilestoneCanceled:
+	default:
+		return in, fmt.Errorf("invalid milestone status %q", in.Status)
+	}
+	return in, nil
+}
+
+func validateMilestoneTarget(target time.Time, project *Project) error {
+	if truncateDay(target).Before(truncateDay(project.StartDate)) {
+		return errors.New("target date cannot be before project start")
+	}
+	if project.EndDate != nil && truncateDay(target).After(truncateDay(*project.EndDate)) {
+		return errors.New("target date cannot be after project end")
+	}
+	return nil
+}
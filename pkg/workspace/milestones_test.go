@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMilestones_TaskDueDatesAndProgress(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC())
+	end := start.AddDate(0, 2, 0)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, EndDate: &end, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	if _, err := projectSvc.CreateMilestone(ctx, project.ID, MilestoneInput{Name: "Beta", TargetDate: end.AddDate(0, 0, 1)}); err == nil {
+		t.Fatal("expected target date after project end to be rejected")
+	}
+	target := start.AddDate(0, 0, 14)
+	milestone, err := projectSvc.CreateMilestone(ctx, project.ID, MilestoneInput{Name: "MVP", TargetDate: target})
+	if err != nil {
+		t.Fatalf("create milestone: %v", err)
+	}
+	if milestone.Status != MilestonePlanned {
+		t.Fatalf("expected planned milestone, got %q", milestone.Status)
+	}
+
+	late := target.AddDate(0, 0, 1)
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7, DueDate: &late, MilestoneID: &milestone.ID}); err == nil {
+		t.Fatal("expected task due after the milestone to be rejected")
+	}
+	login, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7, DueDate: &target, MilestoneID: &milestone.ID})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	signup, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Cadastro", AssigneeID: 7, MilestoneID: &milestone.ID})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, signup.ID, TaskUpdateInput{Title: "Cadastro", Status: TaskTodo, AssigneeID: 7, DueDate: &late, MilestoneID: &milestone.ID}); err == nil {
+		t.Fatal("expected update past the milestone target to be rejected")
+	}
+	if _, err := taskSvc.UpdateTask(ctx, login.ID, TaskUpdateInput{Title: "Login", Status: TaskDone, AssigneeID: 7, DueDate: &target, MilestoneID: &milestone.ID}); err != nil {
+		t.Fatalf("complete task: %v", err)
+	}
+
+	if _, err := projectSvc.UpdateMilestone(ctx, milestone.ID, MilestoneInput{Name: "MVP", TargetDate: target.AddDate(0, 0, -1)}); err == nil {
+		t.Fatal("expected target date before an attached task due date to be rejected")
+	}
+
+	got, err := projectSvc.GetMilestone(ctx, milestone.ID)
+	if err != nil {
+		t.Fatalf("get milestone: %v", err)
+	}
+	if got.Progress.TotalTasks != 2 || got.Progress.DoneTasks != 1 || got.Progress.PercentDone != 50 || got.Progress.TasksByStatus[TaskTodo] != 1 {
+		t.Fatalf("unexpected progress: %+v", got.Progress)
+	}
+
+	if err := projectSvc.DeleteMilestone(ctx, milestone.ID); err != nil {
+		t.Fatalf("delete milestone: %v", err)
+	}
+	detached, err := taskSvc.GetTask(ctx, login.ID)
+	if err != nil {
+		t.Fatalf("get task: %v", err)
+	}
+	if detached.MilestoneID != nil {
+		t.Fatalf("expected task to be detached, got milestone %d", *detached.MilestoneID)
+	}
+}
@@ -1,6 +1,10 @@
 package workspace
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // ProjectStatus expresses lifecycle of a project.
 type ProjectStatus string
@@ -12,6 +16,16 @@ const (
 	ProjectCanceled  ProjectStatus = "canceled"
 )
 
+// ProjectRole is the access level of a project member. Each role includes
+// the rights of the ones before it.
+type ProjectRole string
+
+const (
+	ProjectViewer      ProjectRole = "viewer"
+	ProjectContributor ProjectRole = "contributor"
+	ProjectManager     ProjectRole = "manager"
+)
+
 // TaskStatus expresses lifecycle of a task.
 type TaskStatus string
 
@@ -22,44 +36,428 @@ const (
 	TaskDone       TaskStatus = "done"
 )
 
-// Project is the root entity of the delivery domain.
+// MilestoneStatus expresses lifecycle of a milestone.
+type MilestoneStatus string
+
+const (
+	MilestonePlanned    MilestoneStatus = "planned"
+	MilestoneInProgress MilestoneStatus = "in_progress"
+	MilestoneCompleted  MilestoneStatus = "completed"
+	MilestoneCanceled   MilestoneStatus = "canceled"
+)
+
+// TimeEntryStatus expresses the review state of a time entry.
+type TimeEntryStatus string
+
+const (
+	TimeEntryPending  TimeEntryStatus = "pending"
+	TimeEntryApproved TimeEntryStatus = "approved"
+	TimeEntryRejected TimeEntryStatus = "rejected"
+)
+
+// TimesheetStatus expresses lifecycle of a weekly timesheet.
+type TimesheetStatus string
+
+const (
+	TimesheetDraft     TimesheetStatus = "draft"
+	TimesheetSubmitted TimesheetStatus = "submitted"
+	TimesheetApproved  TimesheetStatus = "approved"
+)
+
+// TimerStatus expresses lifecycle of a running timer.
+type TimerStatus string
+
+const (
+	TimerRunning TimerStatus = "running"
+	TimerPaused  TimerStatus = "paused"
+	TimerStopped TimerStatus = "stopped"
+)
+
+// WeekendRule tells how entries dated on weekends are treated.
+type WeekendRule string
+
+const (
+	WeekendAllow WeekendRule = "allow"
+	WeekendWarn  WeekendRule = "warn"
+	WeekendBlock WeekendRule = "block"
+)
+
+// InvoiceStatus expresses lifecycle of an invoice.
+type InvoiceStatus string
+
+const (
+	InvoiceDraft  InvoiceStatus = "draft"
+	InvoiceIssued InvoiceStatus = "issued"
+	InvoicePaid   InvoiceStatus = "paid"
+	InvoiceVoid   InvoiceStatus = "void"
+)
+
+// Project is the root entity of the delivery domain. Zero budget values mean
+// the project has no budget; BudgetThresholds are the percentages of the
+// budget that raise an alert once reached. Archived projects (ArchivedAt set)
+// and their tasks and entries are read-only. ClientName mirrors the name of
+// the client for listings, reports and invoices.
 type Project struct {
-	ID          uint          `gorm:"primaryKey"`
-	Name        string        `gorm:"size:120;not null"`
-	ClientName  string        `gorm:"size:120;not null"`
-	Description string        `gorm:"size:500"`
-	Status      ProjectStatus `gorm:"size:20;not null;default:planning"`
-	OwnerID     uint          `gorm:"not null"`
-	StartDate   time.Time     `gorm:"not null"`
-	EndDate     *time.Time    ``
+	ID               uint          `gorm:"primaryKey"`
+	Name             string        `gorm:"size:120;not null"`
+	ClientID         *uint         `gorm:"index"`
+	ClientName       string        `gorm:"size:120;not null"`
+	Description      string        `gorm:"size:500"`
+	Status           ProjectStatus `gorm:"size:20;not null;default:planning"`
+	OwnerID          uint          `gorm:"not null"`
+	StartDate        time.Time     `gorm:"not null"`
+	EndDate          *time.Time    ``
+	BudgetHours      float64       `gorm:"type:numeric(10,2);not null;default:0"`
+	BudgetAmount     float64       `gorm:"type:numeric(12,2);not null;default:0"`
+	BudgetCurrency   string        `gorm:"size:3;not null;default:BRL"`
+	BudgetThresholds []int         `gorm:"type:text;serializer:json"`
+	HardBudget       bool          `gorm:"not null;default:false"`
+	ArchivedAt       *time.Time    `gorm:"index"`
+	ArchivedBy       *uint
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Client is the customer projects are delivered and billed to.
+type Client struct {
+	ID             uint   `gorm:"primaryKey"`
+	Name           string `gorm:"size:120;not null;uniqueIndex"`
+	LegalName      string `gorm:"size:200"`
+	TaxID          string `gorm:"size:30"`
+	BillingEmail   string `gorm:"size:160"`
+	BillingAddress string `gorm:"size:300"`
+	Currency       string `gorm:"size:3;not null;default:BRL"`
+	Notes          string `gorm:"size:500"`
+	Active         bool   `gorm:"not null;default:true"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Contacts       []ClientContact `gorm:"foreignKey:ClientID"`
+}
+
+// ClientContact is a person to reach at a client.
+type ClientContact struct {
+	ID        uint   `gorm:"primaryKey"`
+	ClientID  uint   `gorm:"not null;index"`
+	Name      string `gorm:"size:120;not null"`
+	Email     string `gorm:"size:160"`
+	Phone     string `gorm:"size:40"`
+	Role      string `gorm:"size:80"`
+	IsPrimary bool   `gorm:"not null;default:false"`
+}
+
+// Tag is a label of the shared catalog used to categorize projects and
+// tasks. Names are stored in lower case.
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:50;not null;uniqueIndex"`
+	Color     string `gorm:"size:7"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProjectTag links a tag to a project.
+type ProjectTag struct {
+	ProjectID uint `gorm:"primaryKey"`
+	TagID     uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// TaskTag links a tag to a task.
+type TaskTag struct {
+	TaskID    uint `gorm:"primaryKey"`
+	TagID     uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// TaskDependency is a finish-to-start link: the task cannot start before the
+// predecessor is done. Both tasks belong to the same project.
+type TaskDependency struct {
+	TaskID        uint `gorm:"primaryKey"`
+	PredecessorID uint `gorm:"primaryKey;index"`
+	CreatedAt     time.Time
+}
+
+// TaskComment is a message in the discussion thread of a task. Edits keep the
+// previous body as a TaskCommentRevision and deletes are soft.
+type TaskComment struct {
+	ID        uint   `gorm:"primaryKey"`
+	TaskID    uint   `gorm:"not null;index"`
+	AuthorID  uint   `gorm:"not null;index"`
+	Body      string `gorm:"size:2000;not null"`
+	EditedAt  *time.Time
+	DeletedBy *uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// TaskCommentRevision is the body of a comment before one of its edits.
+type TaskCommentRevision struct {
+	ID        uint   `gorm:"primaryKey"`
+	CommentID uint   `gorm:"not null;index"`
+	Body      string `gorm:"size:2000;not null"`
+	EditedBy  uint   `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// TaskAttachment is a file attached to a task. The content lives in the blob
+// store under StorageKey; Checksum is its SHA-256 in hex.
+type TaskAttachment struct {
+	ID          uint   `gorm:"primaryKey"`
+	TaskID      uint   `gorm:"not null;index"`
+	FileName    string `gorm:"size:255;not null"`
+	ContentType string `gorm:"size:120;not null"`
+	SizeBytes   int64  `gorm:"not null"`
+	Checksum    string `gorm:"size:64;not null"`
+	StorageKey  string `gorm:"size:300;not null;uniqueIndex"`
+	UploadedBy  uint   `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// ProjectTemplate is a reusable set of milestones and tasks. Dates are kept
+// as day offsets from the start date of the project created from it.
+type ProjectTemplate struct {
+	ID          uint   `gorm:"primaryKey"`
+	Name        string `gorm:"size:120;not null"`
+	Description string `gorm:"size:500"`
+	CreatedBy   uint   `gorm:"not null"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Milestones  []ProjectTemplateMilestone `gorm:"foreignKey:TemplateID"`
+	Tasks       []ProjectTemplateTask      `gorm:"foreignKey:TemplateID"`
 }
 
-// Task represents work units inside a project.
+// ProjectTemplateMilestone is a milestone of a template.
+type ProjectTemplateMilestone struct {
+	ID               uint   `gorm:"primaryKey"`
+	TemplateID       uint   `gorm:"not null;index"`
+	Name             string `gorm:"size:120;not null"`
+	Description      string `gorm:"size:500"`
+	TargetOffsetDays int    `gorm:"not null"`
+}
+
+// ProjectTemplateTask is a task of a template. MilestoneID and ParentID
+// point to a ProjectTemplateMilestone and a ProjectTemplateTask of the same
+// template.
+type ProjectTemplateTask struct {
+	ID            uint   `gorm:"primaryKey"`
+	TemplateID    uint   `gorm:"not null;index"`
+	Title         string `gorm:"size:150;not null"`
+	Description   string `gorm:"size:500"`
+	AssigneeID    uint   `gorm:"not null"`
+	DueOffsetDays *int
+	EstimateHours float64 `gorm:"type:numeric(7,2);not null;default:0"`
+	MilestoneID   *uint
+	ParentID      *uint
+}
+
+// Task represents work units inside a project. ParentID nests it under
+// another task of the same project, at any depth. EstimateHours is the
+// original estimate; RemainingEstimateHours, when set, replaces the remaining
+// work derived from the hours logged so far.
 type Task struct {
-	ID          uint       `gorm:"primaryKey"`
-	ProjectID   uint       `gorm:"not null"`
-	Title       string     `gorm:"size:150;not null"`
-	Description string     `gorm:"size:500"`
-	Status      TaskStatus `gorm:"size:20;not null;default:todo"`
-	AssigneeID  uint       `gorm:"not null"`
-	DueDate     *time.Time
+	ID                     uint       `gorm:"primaryKey"`
+	ProjectID              uint       `gorm:"not null"`
+	Title                  string     `gorm:"size:150;not null"`
+	Description            string     `gorm:"size:500"`
+	Status                 TaskStatus `gorm:"size:20;not null;default:todo"`
+	AssigneeID             uint       `gorm:"not null"`
+	DueDate                *time.Time
+	EstimateHours          float64  `gorm:"type:numeric(7,2);not null;default:0"`
+	RemainingEstimateHours *float64 `gorm:"type:numeric(7,2)"`
+	MilestoneID            *uint    `gorm:"index"`
+	ParentID               *uint    `gorm:"index"`
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	Project                Project `gorm:"foreignKey:ProjectID"`
+}
+
+// Milestone is a delivery inside a project. Tasks attached to it must be due
+// by its TargetDate.
+type Milestone struct {
+	ID          uint            `gorm:"primaryKey"`
+	ProjectID   uint            `gorm:"not null;index"`
+	Name        string          `gorm:"size:120;not null"`
+	Description string          `gorm:"size:500"`
+	TargetDate  time.Time       `gorm:"not null"`
+	Status      MilestoneStatus `gorm:"size:20;not null;default:planned"`
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
-	Project     Project `gorm:"foreignKey:ProjectID"`
 }
 
 // TimeEntry tracks time spent on tasks.
 type TimeEntry struct {
-	ID         uint      `gorm:"primaryKey"`
-	TaskID     uint      `gorm:"not null"`
-	UserID     uint      `gorm:"not null"`
-	EntryDate  time.Time `gorm:"not null"`
-	Hours      float64   `gorm:"type:numeric(5,2);not null"`
-	Notes      string    `gorm:"size:255"`
-	ApprovedAt *time.Time
-	ApprovedBy *uint
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID              uint            `gorm:"primaryKey"`
+	TaskID          uint            `gorm:"not null"`
+	UserID          uint            `gorm:"not null"`
+	EntryDate       time.Time       `gorm:"not null"`
+	Hours           float64         `gorm:"type:numeric(5,2);not null"`
+	Notes           string          `gorm:"size:255"`
+	Status          TimeEntryStatus `gorm:"size:20;not null;default:pending"`
+	ApprovedAt      *time.Time
+	ApprovedBy      *uint
+	RejectedAt      *time.Time
+	RejectedBy      *uint
+	RejectionReason string `gorm:"size:500"`
+	ReopenedAt      *time.Time
+	ReopenedBy      *uint
+	ReopenReason    string   `gorm:"size:500"`
+	Warnings        []string `gorm:"type:text;serializer:json"`
+	InvoiceID       *uint    `gorm:"index"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Timesheet groups the time entries of a user in one ISO week.
+type Timesheet struct {
+	ID              uint            `gorm:"primaryKey"`
+	UserID          uint            `gorm:"not null;uniqueIndex:idx_timesheets_user_week"`
+	Year            int             `gorm:"not null;uniqueIndex:idx_timesheets_user_week"`
+	Week            int             `gorm:"not null;uniqueIndex:idx_timesheets_user_week"`
+	Status          TimesheetStatus `gorm:"size:20;not null;default:draft"`
+	SubmittedAt     *time.Time
+	ApprovedAt      *time.Time
+	ApprovedBy      *uint
+	RejectedAt      *time.Time
+	RejectedBy      *uint
+	RejectionReason string `gorm:"size:500"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Timer measures work on a task until it is stopped and logged.
+type Timer struct {
+	ID             uint        `gorm:"primaryKey"`
+	UserID         uint        `gorm:"not null;index;uniqueIndex:idx_timers_one_active_per_user,where:status <> 'stopped'"`
+	TaskID         uint        `gorm:"not null"`
+	Status         TimerStatus `gorm:"size:20;not null;default:running"`
+	Notes          string      `gorm:"size:255"`
+	StartedAt      time.Time   `gorm:"not null"`
+	LastResumedAt  *time.Time
+	ElapsedSeconds int64 `gorm:"not null;default:0"`
+	StoppedAt      *time.Time
+	AutoStopped    bool `gorm:"not null;default:false"`
+	TimeEntryID    *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// HourPolicy limits the aggregate hours a user may log. Zero values disable
+// the corresponding rule; UserID zero denotes the system-wide default.
+type HourPolicy struct {
+	ID                  uint        `gorm:"primaryKey"`
+	UserID              uint        `gorm:"not null;uniqueIndex"`
+	MaxDailyHours       float64     `gorm:"type:numeric(5,2);not null;default:0"`
+	MaxWeeklyHours      float64     `gorm:"type:numeric(6,2);not null;default:0"`
+	DailyOvertimeHours  float64     `gorm:"type:numeric(5,2);not null;default:0"`
+	WeeklyOvertimeHours float64     `gorm:"type:numeric(6,2);not null;default:0"`
+	WeekendRule         WeekendRule `gorm:"size:10;not null;default:allow"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// BillingRate is an hourly rate for a project, a user or a project+user pair,
+// valid from EffectiveFrom until EffectiveTo (open-ended when nil).
+type BillingRate struct {
+	ID            uint      `gorm:"primaryKey"`
+	ProjectID     *uint     `gorm:"index"`
+	UserID        *uint     `gorm:"index"`
+	HourlyRate    float64   `gorm:"type:numeric(10,2);not null"`
+	Currency      string    `gorm:"size:3;not null;default:BRL"`
+	EffectiveFrom time.Time `gorm:"not null"`
+	EffectiveTo   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Invoice snapshots approved time entries of a project and period.
+type Invoice struct {
+	ID          uint          `gorm:"primaryKey"`
+	Number      string        `gorm:"size:30;uniqueIndex"`
+	ProjectID   uint          `gorm:"not null;index"`
+	ClientName  string        `gorm:"size:120;not null"`
+	PeriodStart time.Time     `gorm:"not null"`
+	PeriodEnd   time.Time     `gorm:"not null"`
+	Status      InvoiceStatus `gorm:"size:20;not null;default:draft"`
+	Currency    string        `gorm:"size:3;not null"`
+	TotalHours  float64       `gorm:"type:numeric(10,2);not null"`
+	TotalAmount float64       `gorm:"type:numeric(12,2);not null"`
+	CreatedBy   uint          `gorm:"not null"`
+	IssuedAt    *time.Time
+	PaidAt      *time.Time
+	VoidedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Lines       []InvoiceLine `gorm:"foreignKey:InvoiceID"`
+}
+
+// InvoiceLine is the billed copy of one time entry.
+type InvoiceLine struct {
+	ID          uint      `gorm:"primaryKey"`
+	InvoiceID   uint      `gorm:"not null;index"`
+	TimeEntryID uint      `gorm:"not null;index"`
+	TaskID      uint      `gorm:"not null"`
+	UserID      uint      `gorm:"not null"`
+	EntryDate   time.Time `gorm:"not null"`
+	Description string    `gorm:"size:255"`
+	Hours       float64   `gorm:"type:numeric(5,2);not null"`
+	Rate        float64   `gorm:"type:numeric(10,2);not null"`
+	Amount      float64   `gorm:"type:numeric(12,2);not null"`
+}
+
+// PeriodLock freezes time data dated between PeriodStart and PeriodEnd
+// (whole days, inclusive), for one project or globally when ProjectID is nil.
+// Reopened locks are kept as the audit trail of the close/reopen history.
+type PeriodLock struct {
+	ID           uint      `gorm:"primaryKey"`
+	ProjectID    *uint     `gorm:"index"`
+	PeriodStart  time.Time `gorm:"not null"`
+	PeriodEnd    time.Time `gorm:"not null"`
+	Reason       string    `gorm:"size:255"`
+	ClosedBy     uint      `gorm:"not null"`
+	ClosedAt     time.Time `gorm:"not null"`
+	ReopenedBy   *uint
+	ReopenedAt   *time.Time `gorm:"index"`
+	ReopenReason string     `gorm:"size:255"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ApprovalDelegation hands the approval rights of a project owner to a
+// substitute between StartsOn and EndsOn (whole days, inclusive), for one
+// project or every project the owner has when ProjectID is nil.
+type ApprovalDelegation struct {
+	ID          uint      `gorm:"primaryKey"`
+	DelegatorID uint      `gorm:"not null;index"`
+	DelegateID  uint      `gorm:"not null;index"`
+	ProjectID   *uint     `gorm:"index"`
+	StartsOn    time.Time `gorm:"not null"`
+	EndsOn      time.Time `gorm:"not null"`
+	Reason      string    `gorm:"size:255"`
+	CreatedBy   uint      `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ProjectStatusChange records one status transition of a project. FromStatus
+// is empty for the initial status set on creation.
+type ProjectStatusChange struct {
+	ID         uint          `gorm:"primaryKey"`
+	ProjectID  uint          `gorm:"not null;index"`
+	FromStatus ProjectStatus `gorm:"size:20"`
+	ToStatus   ProjectStatus `gorm:"size:20;not null"`
+	Reason     string        `gorm:"size:500"`
+	ChangedBy  uint          `gorm:"not null"`
+	ChangedAt  time.Time     `gorm:"not null"`
+}
+
+// ProjectMember grants a user a role on a project besides its owner.
+type ProjectMember struct {
+	ID        uint        `gorm:"primaryKey"`
+	ProjectID uint        `gorm:"not null;uniqueIndex:idx_project_members_project_user"`
+	UserID    uint        `gorm:"not null;uniqueIndex:idx_project_members_project_user;index"`
+	Role      ProjectRole `gorm:"size:20;not null"`
+	AddedBy   uint        `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
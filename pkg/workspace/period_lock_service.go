@@ -0,0 +1,162 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrPeriodClosed is returned when time data dated inside a closed
+// accounting period would be created, changed or approved.
+var ErrPeriodClosed = errors.New("accounting period is closed")
+
+// PeriodCloseInput describes a period to close. A nil ProjectID closes the
+// period for every project.
+type PeriodCloseInput struct {
+	ProjectID   *uint
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Reason      string
+	ClosedBy    uint
+}
+
+// PeriodLockFilter holds filters for lock listings.
+type PeriodLockFilter struct {
+	ProjectID *uint
+	Active    *bool
+}
+
+// PeriodLockService closes and reopens accounting periods.
+type PeriodLockService struct {
+	db *gorm.DB
+}
+
+func NewPeriodLockService(db *gorm.DB) *PeriodLockService {
+	return &PeriodLockService{db: db}
+}
+
+// ClosePeriod locks the date range. Overlapping an active lock of the same
+// scope is rejected so each day is closed at most once per scope.
+func (s *PeriodLockService) ClosePeriod(ctx context.Context, in PeriodCloseInput) (*PeriodLock, error) {
+	if in.ClosedBy == 0 {
+		return nil, errors.New("closer is required")
+	}
+	if in.PeriodStart.IsZero() || in.PeriodEnd.IsZero() {
+		return nil, errors.New("period start and end are required")
+	}
+	start, end := truncateDay(in.PeriodStart), truncateDay(in.PeriodEnd)
+	if end.Before(start) {
+		return nil, errors.New("period end cannot be before period start")
+	}
+
+	var lock PeriodLock
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if in.ProjectID != nil {
+			if err := tx.First(&Project{}, *in.ProjectID).Error; err != nil {
+				return err
+			}
+		}
+		overlap := tx.Model(&PeriodLock{}).
+			Where("reopened_at IS NULL AND period_start <= ? AND period_end >= ?", end, start)
+		if in.ProjectID != nil {
+			overlap = overlap.Where("project_id = ?", *in.ProjectID)
+		} else {
+			overlap = overlap.Where("project_id IS NULL")
+		}
+		var count int64
+		if err := overlap.Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("period overlaps an already closed period")
+		}
+
+		lock = PeriodLock{
+			ProjectID:   in.ProjectID,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			Reason:      strings.TrimSpace(in.Reason),
+			ClosedBy:    in.ClosedBy,
+			ClosedAt:    time.Now().UTC(),
+		}
+		return tx.Create(&lock).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// ReopenPeriod lifts a lock, recording who reopened it and why.
+func (s *PeriodLockService) ReopenPeriod(ctx context.Context, id uint, adminID uint, reason string) (*PeriodLock, error) {
+	if adminID == 0 {
+		return nil, errors.New("admin is required")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("reopen reason is required")
+	}
+	var lock PeriodLock
+	if err := s.db.WithContext(ctx).First(&lock, id).Error; err != nil {
+		return nil, err
+	}
+	if lock.ReopenedAt != nil {
+		return nil, errors.New("period is already reopened")
+	}
+	now := time.Now().UTC()
+	lock.ReopenedAt = &now
+	lock.ReopenedBy = &adminID
+	lock.ReopenReason = reason
+	if err := s.db.WithContext(ctx).Save(&lock).Error; err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+func (s *PeriodLockService) GetLock(ctx context.Context, id uint) (*PeriodLock, error) {
+	var lock PeriodLock
+	if err := s.db.WithContext(ctx).First(&lock, id).Error; err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// ListLocks returns the close/reopen history, most recent first.
+func (s *PeriodLockService) ListLocks(ctx context.Context, filter PeriodLockFilter) ([]PeriodLock, error) {
+	tx := s.db.WithContext(ctx).Model(&PeriodLock{})
+	if filter.ProjectID != nil {
+		tx = tx.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.Active != nil {
+		if *filter.Active {
+			tx = tx.Where("reopened_at IS NULL")
+		} else {
+			tx = tx.Where("reopened_at IS NOT NULL")
+		}
+	}
+	var locks []PeriodLock
+	if err := tx.Order("period_start DESC, id DESC").Find(&locks).Error; err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+// ensurePeriodOpen rejects changes to time data of the project dated inside
+// an active global or project lock.
+func ensurePeriodOpen(tx *gorm.DB, projectID uint, date time.Time) error {
+	day := truncateDay(date)
+	var count int64
+	if err := tx.Model(&PeriodLock{}).
+		Where("reopened_at IS NULL AND period_start <= ? AND period_end >= ?", day, day).
+		Where("project_id IS NULL OR project_id = ?", projectID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrPeriodClosed
+	}
+	return nil
+}
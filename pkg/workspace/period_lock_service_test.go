@@ -0,0 +1,181 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodLockService_FreezesTimeData(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	periodSvc := NewPeriodLockService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -20))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Portal",
+		ClientName: "ACME",
+		StartDate:  start,
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	other, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "App",
+		ClientName: "ACME",
+		StartDate:  start,
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	otherTask, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: other.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	pending, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2).Add(15 * time.Hour), Hours: 2})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	lock, err := periodSvc.ClosePeriod(ctx, PeriodCloseInput{
+		ProjectID:   &project.ID,
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 0, 6),
+		Reason:      "Fechamento mensal",
+		ClosedBy:    1,
+	})
+	if err != nil {
+		t.Fatalf("close period: %v", err)
+	}
+
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 6).Add(23 * time.Hour), Hours: 1}); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed on last day, got %v", err)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, pending.ID, TimeEntryUpdateInput{EntryDate: start.AddDate(0, 0, 8), Hours: 2}); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed moving entry out, got %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, pending.ID, 1); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed on approve, got %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 7), Hours: 1}); err != nil {
+		t.Fatalf("day after the period must stay open: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: otherTask.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2), Hours: 1}); err != nil {
+		t.Fatalf("other project must stay open: %v", err)
+	}
+
+	if _, err := periodSvc.ClosePeriod(ctx, PeriodCloseInput{ProjectID: &project.ID, PeriodStart: start.AddDate(0, 0, 5), PeriodEnd: start.AddDate(0, 0, 9), ClosedBy: 1}); err == nil {
+		t.Fatalf("expected overlapping close to fail")
+	}
+	if _, err := periodSvc.ReopenPeriod(ctx, lock.ID, 1, ""); err == nil {
+		t.Fatalf("expected reopen without reason to fail")
+	}
+	reopened, err := periodSvc.ReopenPeriod(ctx, lock.ID, 1, "Ajuste de horas")
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if reopened.ReopenedBy == nil || *reopened.ReopenedBy != 1 || reopened.ReopenedAt == nil {
+		t.Fatalf("expected reopen audit fields, got %+v", reopened)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, pending.ID, 1); err != nil {
+		t.Fatalf("approve after reopen: %v", err)
+	}
+
+	if _, err := periodSvc.ClosePeriod(ctx, PeriodCloseInput{PeriodStart: start, PeriodEnd: start.AddDate(0, 0, 3), ClosedBy: 1}); err != nil {
+		t.Fatalf("close global period: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: otherTask.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 1}); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected global lock to apply to every project, got %v", err)
+	}
+
+	history, err := periodSvc.ListLocks(ctx, PeriodLockFilter{})
+	if err != nil {
+		t.Fatalf("list locks: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected reopened lock to be kept in history, got %d locks", len(history))
+	}
+	active := true
+	current, err := periodSvc.ListLocks(ctx, PeriodLockFilter{Active: &active})
+	if err != nil {
+		t.Fatalf("list active locks: %v", err)
+	}
+	if len(current) != 1 || current[0].ProjectID != nil {
+		t.Fatalf("expected only the global lock to be active, got %+v", current)
+	}
+}
+
+func TestPeriodLockService_FreezesReviews(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	periodSvc := NewPeriodLockService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -20))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	var entries [3]*TimeEntry
+	for i := range entries {
+		entries[i], err = timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.AddDate(0, 0, i+1), Hours: 2})
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+	}
+	pending, rejected, approved := entries[0], entries[1], entries[2]
+	if _, err := timeSvc.RejectEntry(ctx, rejected.ID, 1, "faltou descrição"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, approved.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := periodSvc.ClosePeriod(ctx, PeriodCloseInput{ProjectID: &project.ID, PeriodStart: start, PeriodEnd: start.AddDate(0, 0, 6), ClosedBy: 1}); err != nil {
+		t.Fatalf("close period: %v", err)
+	}
+
+	if _, err := timeSvc.RejectEntry(ctx, pending.ID, 1, "horas erradas"); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed on reject, got %v", err)
+	}
+	result, err := timeSvc.BulkReview(ctx, BulkReviewInput{Action: BulkReject, IDs: []uint{pending.ID}, ReviewerID: 1, Reason: "horas erradas"})
+	if err != nil {
+		t.Fatalf("bulk reject: %v", err)
+	}
+	if result.Failed != 1 || result.Items[0].Error != ErrPeriodClosed.Error() {
+		t.Fatalf("expected the bulk reject to fail on the closed period, got %+v", result.Items)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, rejected.ID); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed on resubmit, got %v", err)
+	}
+	if _, err := timeSvc.ReopenEntry(ctx, approved.ID, 1, "ajuste"); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed on reopen, got %v", err)
+	}
+
+	for id, want := range map[uint]TimeEntryStatus{pending.ID: TimeEntryPending, rejected.ID: TimeEntryRejected, approved.ID: TimeEntryApproved} {
+		entry, err := timeSvc.GetEntry(ctx, id)
+		if err != nil {
+			t.Fatalf("get entry: %v", err)
+		}
+		if entry.Status != want {
+			t.Fatalf("expected entry %d to stay %s, got %s", id, want, entry.Status)
+		}
+	}
+}
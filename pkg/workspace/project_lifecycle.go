@@ -0,0 +1,175 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rules reported in TransitionBlocker.Rule.
+const (
+	RuleTasksNotDone          = "tasks_not_done"
+	RuleUnapprovedTimeEntries = "unapproved_time_entries"
+)
+
+// LifecycleRules toggles the checks run before a project is completed.
+type LifecycleRules struct {
+	RequireTasksDone       bool
+	RequireApprovedEntries bool
+}
+
+// DefaultLifecycleRules enables every completion check.
+func DefaultLifecycleRules() LifecycleRules {
+	return LifecycleRules{RequireTasksDone: true, RequireApprovedEntries: true}
+}
+
+// TransitionBlocker is one unmet requirement of a status transition, with the
+// IDs of the tasks or time entries that must be resolved.
+type TransitionBlocker struct {
+	Rule    string
+	Message string
+	IDs     []uint
+}
+
+// TransitionBlockedError lists every blocker found for a status transition.
+type TransitionBlockedError struct {
+	From     ProjectStatus
+	To       ProjectStatus
+	Blockers []TransitionBlocker
+}
+
+func (e *TransitionBlockedError) Error() string {
+	messages := make([]string, len(e.Blockers))
+	for i, blocker := range e.Blockers {
+		messages[i] = blocker.Message
+	}
+	return fmt.Sprintf("cannot move project from %s to %s: %s", e.From, e.To, strings.Join(messages, "; "))
+}
+
+// ProjectTransitionInput moves a project to another status.
+type ProjectTransitionInput struct {
+	Status    ProjectStatus
+	Reason    string
+	ChangedBy uint
+}
+
+// WithLifecycleRules sets the checks applied to status transitions.
+func (s *ProjectService) WithLifecycleRules(rules LifecycleRules) *ProjectService {
+	s.rules = rules
+	return s
+}
+
+// TransitionProject changes the status of a project after checking the
+// lifecycle rules and records the change in its history.
+func (s *ProjectService) TransitionProject(ctx context.Context, id uint, in ProjectTransitionInput) (*Project, error) {
+	var project Project
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&project, id).Error; err != nil {
+			return err
+		}
+		if project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if project.Status == in.Status {
+			return fmt.Errorf("project is already %s", in.Status)
+		}
+		if err := s.transition(tx, &project, in.Status, in.Reason, in.ChangedBy); err != nil {
+			return err
+		}
+		return tx.Save(&project).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// StatusHistory returns the status changes of a project, oldest first.
+func (s *ProjectService) StatusHistory(ctx context.Context, id uint) ([]ProjectStatusChange, error) {
+	var changes []ProjectStatusChange
+	if err := s.db.WithContext(ctx).
+		Where("project_id = ?", id).
+		Order("changed_at, id").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// transition validates the move of project to next and records it; the
+// caller saves the project.
+func (s *ProjectService) transition(tx *gorm.DB, project *Project, next ProjectStatus, reason string, changedBy uint) error {
+	switch next {
+	case ProjectPlanning, ProjectActive, ProjectCompleted, ProjectCanceled:
+	default:
+		return fmt.Errorf("invalid project status %q", next)
+	}
+	if err := validateProjectStatusTransition(project.Status, next); err != nil {
+		return err
+	}
+	if next == ProjectCompleted {
+		blockers, err := s.completionBlockers(tx, project.ID)
+		if err != nil {
+			return err
+		}
+		if len(blockers) > 0 {
+			return &TransitionBlockedError{From: project.Status, To: next, Blockers: blockers}
+		}
+	}
+
+	change := ProjectStatusChange{
+		ProjectID:  project.ID,
+		FromStatus: project.Status,
+		ToStatus:   next,
+		Reason:     strings.TrimSpace(reason),
+		ChangedBy:  changedBy,
+		ChangedAt:  time.Now().UTC(),
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return err
+	}
+	project.Status = next
+	return nil
+}
+
+func (s *ProjectService) completionBlockers(tx *gorm.DB, projectID uint) ([]TransitionBlocker, error) {
+	var blockers []TransitionBlocker
+	if s.rules.RequireTasksDone {
+		var ids []uint
+		if err := tx.Model(&Task{}).
+			Where("project_id = ? AND status <> ?", projectID, TaskDone).
+			Order("id").
+			Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			blockers = append(blockers, TransitionBlocker{
+				Rule:    RuleTasksNotDone,
+				Message: fmt.Sprintf("%d task(s) not done", len(ids)),
+				IDs:     ids,
+			})
+		}
+	}
+	if s.rules.RequireApprovedEntries {
+		var ids []uint
+		if err := tx.Model(&TimeEntry{}).
+			Where("task_id IN (?)", tx.Session(&gorm.Session{NewDB: true}).
+				Model(&Task{}).Select("id").Where("project_id = ?", projectID)).
+			Where("status <> ?", TimeEntryApproved).
+			Order("id").
+			Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			blockers = append(blockers, TransitionBlocker{
+				Rule:    RuleUnapprovedTimeEntries,
+				Message: fmt.Sprintf("%d time entry(ies) not approved", len(ids)),
+				IDs:     ids,
+			})
+		}
+	}
+	return blockers, nil
+}
@@ -0,0 +1,79 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProjectLifecycle_CompletionBlockersAndHistory(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().AddDate(0, 0, -5)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Portal",
+		ClientName: "ACME",
+		StartDate:  start,
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	entry, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: start.Add(time.Hour), Hours: 2})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	if _, err := projectSvc.TransitionProject(ctx, project.ID, ProjectTransitionInput{Status: ProjectActive, Reason: "kickoff", ChangedBy: 1}); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	_, err = projectSvc.TransitionProject(ctx, project.ID, ProjectTransitionInput{Status: ProjectCompleted, ChangedBy: 1})
+	var blocked *TransitionBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("expected TransitionBlockedError, got %v", err)
+	}
+	if len(blocked.Blockers) != 2 ||
+		blocked.Blockers[0].Rule != RuleTasksNotDone || blocked.Blockers[0].IDs[0] != task.ID ||
+		blocked.Blockers[1].Rule != RuleUnapprovedTimeEntries || blocked.Blockers[1].IDs[0] != entry.ID {
+		t.Fatalf("unexpected blockers: %+v", blocked.Blockers)
+	}
+
+	// PUT com mudança de status passa pelas mesmas regras
+	if _, err := projectSvc.UpdateProject(ctx, project.ID, ProjectUpdateInput{Name: "Portal", ClientName: "ACME", Status: ProjectCompleted, StartDate: start, UpdatedBy: 1}); !errors.As(err, &blocked) {
+		t.Fatalf("expected update to be blocked, got %v", err)
+	}
+
+	relaxed := NewProjectService(db).WithLifecycleRules(LifecycleRules{RequireTasksDone: true})
+	if _, err := taskSvc.UpdateTask(ctx, task.ID, TaskUpdateInput{Title: "Login", Status: TaskDone, AssigneeID: 7}); err != nil {
+		t.Fatalf("finish task: %v", err)
+	}
+	if _, err := relaxed.TransitionProject(ctx, project.ID, ProjectTransitionInput{Status: ProjectCompleted, Reason: "entregue", ChangedBy: 2}); err != nil {
+		t.Fatalf("complete with approval rule disabled: %v", err)
+	}
+	if _, err := projectSvc.TransitionProject(ctx, project.ID, ProjectTransitionInput{Status: ProjectActive, ChangedBy: 1}); err == nil {
+		t.Fatal("expected completed project to stay terminal")
+	}
+
+	history, err := projectSvc.StatusHistory(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 status changes, got %+v", history)
+	}
+	if history[0].FromStatus != "" || history[0].ToStatus != ProjectPlanning ||
+		history[1].ToStatus != ProjectActive || history[1].Reason != "kickoff" ||
+		history[2].FromStatus != ProjectActive || history[2].ToStatus != ProjectCompleted || history[2].ChangedBy != 2 {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+}
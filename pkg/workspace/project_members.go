@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var projectRoleRank = map[ProjectRole]int{
+	ProjectViewer:      1,
+	ProjectContributor: 2,
+	ProjectManager:     3,
+}
+
+// ParseProjectRole validates a role name.
+func ParseProjectRole(value string) (ProjectRole, error) {
+	role := ProjectRole(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := projectRoleRank[role]; !ok {
+		return "", fmt.Errorf("invalid project role %q", value)
+	}
+	return role, nil
+}
+
+// AtLeast reports whether the role grants the rights of min. The empty role
+// (not a member) grants nothing.
+func (r ProjectRole) AtLeast(min ProjectRole) bool {
+	rank, ok := projectRoleRank[r]
+	return ok && rank >= projectRoleRank[min]
+}
+
+// AddMember adds a user to the project or changes their role.
+func (s *ProjectService) AddMember(ctx context.Context, projectID, userID uint, role ProjectRole, addedBy uint) (*ProjectMember, error) {
+	if userID == 0 {
+		return nil, errors.New("user is required")
+	}
+	if _, ok := projectRoleRank[role]; !ok {
+		return nil, fmt.Errorf("invalid project role %q", role)
+	}
+	project, err := s.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.OwnerID == userID {
+		return nil, errors.New("project owner already manages the project")
+	}
+
+	var member ProjectMember
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("project_id = ? AND user_id = ?", projectID, userID).First(&member).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			member = ProjectMember{ProjectID: projectID, UserID: userID, Role: role, AddedBy: addedBy}
+			return tx.Create(&member).Error
+		}
+		if err != nil {
+			return err
+		}
+		member.Role = role
+		return tx.Save(&member).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (s *ProjectService) RemoveMember(ctx context.Context, projectID, userID uint) error {
+	res := s.db.WithContext(ctx).
+		Where("project_id = ? AND user_id = ?", projectID, userID).
+		Delete(&ProjectMember{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (s *ProjectService) ListMembers(ctx context.Context, projectID uint) ([]ProjectMember, error) {
+	var members []ProjectMember
+	if err := s.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("id").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// MemberRole returns the user's membership role on the project, or the empty
+// role when they are not a member. Ownership is not considered.
+func (s *ProjectService) MemberRole(ctx context.Context, projectID, userID uint) (ProjectRole, error) {
+	var member ProjectMember
+	err := s.db.WithContext(ctx).
+		Where("project_id = ? AND user_id = ?", projectID, userID).
+		First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
@@ -0,0 +1,103 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProjectService_Membership(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	delegationSvc := NewDelegationService(db)
+	ctx := context.Background()
+
+	const owner, manager, developer, outsider = 1, 2, 3, 4
+	start := time.Now().UTC().AddDate(0, 0, -5)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: owner})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if _, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Outro", ClientName: "ACME", StartDate: start, OwnerID: outsider}); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	for _, title := range []string{"Login", "Dashboard"} {
+		if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: title, AssigneeID: outsider}); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+
+	if _, err := projectSvc.AddMember(ctx, project.ID, owner, ProjectViewer, owner); err == nil {
+		t.Fatalf("expected adding the owner as member to fail")
+	}
+	if _, err := projectSvc.AddMember(ctx, project.ID, developer, ProjectRole("admin"), owner); err == nil {
+		t.Fatalf("expected invalid role to fail")
+	}
+	if _, err := projectSvc.AddMember(ctx, project.ID, developer, ProjectViewer, owner); err != nil {
+		t.Fatalf("add viewer: %v", err)
+	}
+	member, err := projectSvc.AddMember(ctx, project.ID, developer, ProjectContributor, owner)
+	if err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if member.Role != ProjectContributor {
+		t.Fatalf("expected role to be updated, got %s", member.Role)
+	}
+	if _, err := projectSvc.AddMember(ctx, project.ID, manager, ProjectManager, owner); err != nil {
+		t.Fatalf("add manager: %v", err)
+	}
+	members, err := projectSvc.ListMembers(ctx, project.ID)
+	if err != nil || len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d (%v)", len(members), err)
+	}
+
+	role, err := projectSvc.MemberRole(ctx, project.ID, developer)
+	if err != nil {
+		t.Fatalf("member role: %v", err)
+	}
+	if !role.AtLeast(ProjectViewer) || !role.AtLeast(ProjectContributor) || role.AtLeast(ProjectManager) {
+		t.Fatalf("unexpected rights for %s", role)
+	}
+	if none, _ := projectSvc.MemberRole(ctx, project.ID, outsider); none.AtLeast(ProjectViewer) {
+		t.Fatalf("non-members must have no rights")
+	}
+
+	visibleTo := uint(developer)
+	projects, err := projectSvc.ListProjects(ctx, ProjectFilter{VisibleTo: &visibleTo})
+	if err != nil {
+		t.Fatalf("list projects: %v", err)
+	}
+	if projects.Total != 1 || projects.Items[0].ID != project.ID {
+		t.Fatalf("member must see only the project they belong to, got %+v", projects.Items)
+	}
+	tasks, err := taskSvc.ListTasks(ctx, TaskFilter{VisibleTo: &visibleTo})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if tasks.Total != 2 {
+		t.Fatalf("member must see every task of the project, got %d", tasks.Total)
+	}
+
+	scope, err := delegationSvc.ReviewScope(ctx, manager, true, time.Now())
+	if err != nil {
+		t.Fatalf("review scope: %v", err)
+	}
+	if !scope.Allows(project.ID, developer) {
+		t.Fatalf("manager members must review like the owner")
+	}
+
+	if err := projectSvc.RemoveMember(ctx, project.ID, developer); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	if err := projectSvc.RemoveMember(ctx, project.ID, developer); err == nil {
+		t.Fatalf("expected removing a non-member to fail")
+	}
+	tasks, err = taskSvc.ListTasks(ctx, TaskFilter{VisibleTo: &visibleTo})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if tasks.Total != 0 {
+		t.Fatalf("removed member must lose access, got %d tasks", tasks.Total)
+	}
+}
@@ -10,10 +10,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrProjectHasInvoices prevents deleting projects with billing history.
+var ErrProjectHasInvoices = errors.New("project has invoices and cannot be deleted")
+
+// ErrProjectHasApprovedHours prevents deleting projects with approved time;
+// such projects are archived instead.
+var ErrProjectHasApprovedHours = errors.New("project has approved hours and cannot be deleted, archive it instead")
+
+// ErrProjectArchived is returned when changing an archived project, its tasks
+// or its time entries.
+var ErrProjectArchived = errors.New("project is archived and read-only")
+
+// ErrProjectHasActiveTimers prevents archiving projects whose tasks still have
+// running or paused timers.
+var ErrProjectHasActiveTimers = errors.New("project has active timers, stop them before archiving")
+
+// ErrProjectNotArchived is returned when unarchiving a project that is not
+// archived.
+var ErrProjectNotArchived = errors.New("project is not archived")
+
 // pagination settings shared by list endpoints.
 const (
 	defaultPageSize = 10
 	maxPageSize     = 50
+	// exportBatchSize is the chunk size used when streaming full result sets.
+	exportBatchSize = 500
 )
 
 // ProjectsPage is returned by list queries.
@@ -26,16 +47,26 @@ type ProjectsPage struct {
 type ProjectFilter struct {
 	Status    []ProjectStatus
 	Client    string
+	ClientID  *uint
 	OwnerID   *uint
 	Page      int
 	PageSize  int
 	FromDate  *time.Time
 	UntilDate *time.Time
+	// Tags keeps the projects that have every one of the named tags.
+	Tags []string
+	// IncludeArchived lists archived projects too; they are hidden by default.
+	IncludeArchived bool
+	// VisibleTo restricts the listing to projects the user owns or is a
+	// member of. Nil means no restriction.
+	VisibleTo *uint
 }
 
-// ProjectInput represents creation payload.
+// ProjectInput represents creation payload. The client is ClientID or, when
+// nil, the registered client named ClientName.
 type ProjectInput struct {
 	Name        string
+	ClientID    *uint
 	ClientName  string
 	Description string
 	StartDate   time.Time
@@ -43,23 +74,27 @@ type ProjectInput struct {
 	OwnerID     uint
 }
 
-// ProjectUpdateInput represents update payload.
+// ProjectUpdateInput represents update payload. A status change goes through
+// the same lifecycle rules as TransitionProject.
 type ProjectUpdateInput struct {
 	Name        string
+	ClientID    *uint
 	ClientName  string
 	Description string
 	Status      ProjectStatus
 	StartDate   time.Time
 	EndDate     *time.Time
+	UpdatedBy   uint
 }
 
 // ProjectService orchestrates use-cases for projects.
 type ProjectService struct {
-	db *gorm.DB
+	db    *gorm.DB
+	rules LifecycleRules
 }
 
 func NewProjectService(db *gorm.DB) *ProjectService {
-	return &ProjectService{db: db}
+	return &ProjectService{db: db, rules: DefaultLifecycleRules()}
 }
 
 func (s *ProjectService) CreateProject(ctx context.Context, in ProjectInput) (*Project, error) {
@@ -67,16 +102,44 @@ func (s *ProjectService) CreateProject(ctx context.Context, in ProjectInput) (*P
 		return nil, err
 	}
 
+	var project *Project
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		project, err = createProject(tx, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// createProject stores a validated project in planning, linked to its client,
+// and records the initial status.
+func createProject(tx *gorm.DB, in ProjectInput) (*Project, error) {
+	client, err := resolveClient(tx, in.ClientID, in.ClientName)
+	if err != nil {
+		return nil, err
+	}
 	project := &Project{
 		Name:        in.Name,
-		ClientName:  in.ClientName,
+		ClientID:    &client.ID,
+		ClientName:  client.Name,
 		Description: in.Description,
 		Status:      ProjectPlanning,
 		OwnerID:     in.OwnerID,
 		StartDate:   in.StartDate,
 		EndDate:     in.EndDate,
 	}
-	if err := s.db.WithContext(ctx).Create(project).Error; err != nil {
+	if err := tx.Create(project).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Create(&ProjectStatusChange{
+		ProjectID: project.ID,
+		ToStatus:  project.Status,
+		ChangedBy: in.OwnerID,
+		ChangedAt: project.CreatedAt,
+	}).Error; err != nil {
 		return nil, err
 	}
 	return project, nil
@@ -88,22 +151,35 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id uint, in ProjectU
 	}
 
 	var project Project
-	if err := s.db.WithContext(ctx).First(&project, id).Error; err != nil {
-		return nil, err
-	}
-
-	if err := validateProjectStatusTransition(project.Status, in.Status); err != nil {
-		return nil, err
-	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&project, id).Error; err != nil {
+			return err
+		}
+		if project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if project.Status != in.Status {
+			if err := s.transition(tx, &project, in.Status, "", in.UpdatedBy); err != nil {
+				return err
+			}
+		}
 
-	project.Name = in.Name
-	project.ClientName = in.ClientName
-	project.Description = in.Description
-	project.StartDate = in.StartDate
-	project.EndDate = in.EndDate
-	project.Status = in.Status
+		if clientChanged(&project, in.ClientID, in.ClientName) {
+			client, err := resolveClient(tx, in.ClientID, in.ClientName)
+			if err != nil {
+				return err
+			}
+			project.ClientID = &client.ID
+			project.ClientName = client.Name
+		}
 
-	if err := s.db.WithContext(ctx).Save(&project).Error; err != nil {
+		project.Name = in.Name
+		project.Description = in.Description
+		project.StartDate = in.StartDate
+		project.EndDate = in.EndDate
+		return tx.Save(&project).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &project, nil
@@ -111,6 +187,14 @@ func (s *ProjectService) UpdateProject(ctx context.Context, id uint, in ProjectU
 
 func (s *ProjectService) DeleteProject(ctx context.Context, id uint) error {
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var invoices int64
+		if err := tx.Model(&Invoice{}).Where("project_id = ?", id).Count(&invoices).Error; err != nil {
+			return err
+		}
+		if invoices > 0 {
+			return ErrProjectHasInvoices
+		}
+
 		var taskIDs []uint
 		if err := tx.Model(&Task{}).
 			Where("project_id = ?", id).
@@ -118,20 +202,116 @@ func (s *ProjectService) DeleteProject(ctx context.Context, id uint) error {
 			return err
 		}
 
+		if len(taskIDs) > 0 {
+			var approved int64
+			if err := tx.Model(&TimeEntry{}).
+				Where("task_id IN ? AND status = ?", taskIDs, TimeEntryApproved).
+				Count(&approved).Error; err != nil {
+				return err
+			}
+			if approved > 0 {
+				return ErrProjectHasApprovedHours
+			}
+
+			var attachments int64
+			if err := tx.Model(&TaskAttachment{}).Where("task_id IN ?", taskIDs).Count(&attachments).Error; err != nil {
+				return err
+			}
+			if attachments > 0 {
+				return ErrProjectHasAttachments
+			}
+		}
+
 		if len(taskIDs) > 0 {
 			if err := tx.Where("task_id IN ?", taskIDs).Delete(&TimeEntry{}).Error; err != nil {
 				return err
 			}
+			if err := tx.Where("task_id IN ?", taskIDs).Delete(&TaskTag{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("task_id IN ?", taskIDs).Delete(&TaskDependency{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("comment_id IN (?)", tx.Session(&gorm.Session{NewDB: true}).
+				Unscoped().Model(&TaskComment{}).Select("id").Where("task_id IN ?", taskIDs)).
+				Delete(&TaskCommentRevision{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("task_id IN ?", taskIDs).Delete(&TaskComment{}).Error; err != nil {
+				return err
+			}
 		}
 
 		if err := tx.Where("project_id = ?", id).Delete(&Task{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("project_id = ?", id).Delete(&ProjectMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&ProjectStatusChange{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&Milestone{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&ProjectTag{}).Error; err != nil {
+			return err
+		}
 
 		return tx.Delete(&Project{}, id).Error
 	})
 }
 
+// ArchiveProject makes the project and its tasks and entries read-only and
+// hides it from listings. Projects with running or paused timers must have
+// them stopped first.
+func (s *ProjectService) ArchiveProject(ctx context.Context, id uint, archivedBy uint) (*Project, error) {
+	var project Project
+	if err := s.db.WithContext(ctx).First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	if project.ArchivedAt != nil {
+		return &project, nil
+	}
+
+	var timers int64
+	if err := s.db.WithContext(ctx).Model(&Timer{}).
+		Where("status <> ?", TimerStopped).
+		Where("task_id IN (?)", s.db.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id = ?", id)).
+		Count(&timers).Error; err != nil {
+		return nil, err
+	}
+	if timers > 0 {
+		return nil, ErrProjectHasActiveTimers
+	}
+
+	now := time.Now().UTC()
+	project.ArchivedAt = &now
+	project.ArchivedBy = &archivedBy
+	if err := s.db.WithContext(ctx).Save(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// UnarchiveProject makes an archived project writable and listed again.
+func (s *ProjectService) UnarchiveProject(ctx context.Context, id uint) (*Project, error) {
+	var project Project
+	if err := s.db.WithContext(ctx).First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	if project.ArchivedAt == nil {
+		return nil, ErrProjectNotArchived
+	}
+	project.ArchivedAt = nil
+	project.ArchivedBy = nil
+	if err := s.db.WithContext(ctx).Save(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
 func (s *ProjectService) GetProject(ctx context.Context, id uint) (*Project, error) {
 	var project Project
 	if err := s.db.WithContext(ctx).First(&project, id).Error; err != nil {
@@ -147,45 +327,71 @@ func (s *ProjectService) ListProjects(ctx context.Context, filter ProjectFilter)
 		total int64
 	)
 
-	tx := s.db.WithContext(ctx).Model(&Project{})
+	tx := applyProjectFilter(s.db.WithContext(ctx).Model(&Project{}), filter)
+
+	if err := tx.Count(&total).Error; err != nil {
+		return ProjectsPage{}, err
+	}
 
+	offset := (filter.Page - 1) * filter.PageSize
+	if err := tx.Order("created_at DESC").
+		Limit(filter.PageSize).
+		Offset(offset).
+		Find(&items).Error; err != nil {
+		return ProjectsPage{}, err
+	}
+
+	return ProjectsPage{Items: items, Total: total}, nil
+}
+
+// EachProject streams every project matching the filter (pagination is
+// ignored) to fn in batches.
+func (s *ProjectService) EachProject(ctx context.Context, filter ProjectFilter, fn func([]Project) error) error {
+	var batch []Project
+	return applyProjectFilter(s.db.WithContext(ctx).Model(&Project{}), filter).
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+func applyProjectFilter(tx *gorm.DB, filter ProjectFilter) *gorm.DB {
 	if len(filter.Status) > 0 {
 		tx = tx.Where("status IN ?", filter.Status)
 	}
 	if filter.Client != "" {
 		tx = tx.Where("LOWER(client_name) LIKE ?", "%"+strings.ToLower(filter.Client)+"%")
 	}
+	if filter.ClientID != nil {
+		tx = tx.Where("client_id = ?", *filter.ClientID)
+	}
 	if filter.OwnerID != nil {
 		tx = tx.Where("owner_id = ?", *filter.OwnerID)
 	}
+	for _, tag := range filter.Tags {
+		tx = tx.Where("id IN (?)", taggedProjects(tx, tag))
+	}
+	if !filter.IncludeArchived {
+		tx = tx.Where("archived_at IS NULL")
+	}
+	if filter.VisibleTo != nil {
+		tx = tx.Where("owner_id = ? OR id IN (?)", *filter.VisibleTo,
+			tx.Session(&gorm.Session{NewDB: true}).Model(&ProjectMember{}).Select("project_id").Where("user_id = ?", *filter.VisibleTo))
+	}
 	if filter.FromDate != nil {
 		tx = tx.Where("start_date >= ?", filter.FromDate)
 	}
 	if filter.UntilDate != nil {
 		tx = tx.Where("start_date <= ?", filter.UntilDate)
 	}
-
-	if err := tx.Count(&total).Error; err != nil {
-		return ProjectsPage{}, err
-	}
-
-	offset := (filter.Page - 1) * filter.PageSize
-	if err := tx.Order("created_at DESC").
-		Limit(filter.PageSize).
-		Offset(offset).
-		Find(&items).Error; err != nil {
-		return ProjectsPage{}, err
-	}
-
-	return ProjectsPage{Items: items, Total: total}, nil
+	return tx
 }
 
 func validateProjectInput(in ProjectInput) error {
 	if strings.TrimSpace(in.Name) == "" {
 		return errors.New("project name is required")
 	}
-	if strings.TrimSpace(in.ClientName) == "" {
-		return errors.New("client name is required")
+	if in.ClientID == nil && strings.TrimSpace(in.ClientName) == "" {
+		return errors.New("client is required")
 	}
 	if in.OwnerID == 0 {
 		return errors.New("owner is required")
@@ -203,8 +409,8 @@ func validateProjectUpdate(in ProjectUpdateInput) error {
 	if strings.TrimSpace(in.Name) == "" {
 		return errors.New("project name is required")
 	}
-	if strings.TrimSpace(in.ClientName) == "" {
-		return errors.New("client name is required")
+	if in.ClientID == nil && strings.TrimSpace(in.ClientName) == "" {
+		return errors.New("client is required")
 	}
 	if in.StartDate.IsZero() {
 		return errors.New("start date is required")
@@ -220,6 +426,15 @@ func validateProjectUpdate(in ProjectUpdateInput) error {
 	return nil
 }
 
+// clientChanged reports whether the update points the project at another
+// client, so unchanged projects of inactive clients can still be edited.
+func clientChanged(project *Project, clientID *uint, clientName string) bool {
+	if clientID != nil {
+		return project.ClientID == nil || *project.ClientID != *clientID
+	}
+	return project.ClientID == nil || !strings.EqualFold(strings.TrimSpace(clientName), project.ClientName)
+}
+
 func validateProjectStatusTransition(current, next ProjectStatus) error {
 	if current == ProjectCompleted || current == ProjectCanceled {
 		if next != current {
@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -12,13 +13,20 @@ import (
 
 func newWorkspaceTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
-	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	dsn := fmt.Sprintf("file:workspace_%d?mode=memory&cache=shared", time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		t.Fatalf("open sqlite: %v", err)
 	}
-	if err := db.AutoMigrate(&Project{}, &Task{}, &TimeEntry{}); err != nil {
+	if err := db.AutoMigrate(&Project{}, &Task{}, &TimeEntry{}, &Timesheet{}, &Timer{}, &HourPolicy{}, &BillingRate{}, &Invoice{}, &InvoiceLine{}, &PeriodLock{}, &ApprovalDelegation{}, &ProjectMember{}, &ProjectStatusChange{}, &Client{}, &ClientContact{}, &Milestone{}, &ProjectTemplate{}, &ProjectTemplateMilestone{}, &ProjectTemplateTask{}, &Tag{}, &ProjectTag{}, &TaskTag{}, &TaskDependency{}, &TaskComment{}, &TaskCommentRevision{}, &TaskAttachment{}); err != nil {
 		t.Fatalf("automigrate: %v", err)
 	}
+	// projetos só referenciam clientes já cadastrados
+	for _, name := range []string{"ACME", "Cliente", "Cliente A", "Globex", "Big Corp", "FinTech", "Retail Inc", "Banco X"} {
+		if err := db.Create(&Client{Name: name, Currency: "BRL", Active: true}).Error; err != nil {
+			t.Fatalf("seed client: %v", err)
+		}
+	}
 	return db
 }
 
@@ -210,6 +218,104 @@ func TestProjectService_DeleteCascade(t *testing.T) {
 	}
 }
 
+func TestProjectService_ArchiveMakesProjectReadOnly(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().AddDate(0, 0, -5)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Legado",
+		ClientName: "Cliente",
+		StartDate:  start,
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Tarefa", AssigneeID: 2})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	approved, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 2, EntryDate: start.Add(time.Hour), Hours: 2})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, approved.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	pending, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 2, EntryDate: start.Add(26 * time.Hour), Hours: 1})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	if err := projectSvc.DeleteProject(ctx, project.ID); !errors.Is(err, ErrProjectHasApprovedHours) {
+		t.Fatalf("expected ErrProjectHasApprovedHours, got %v", err)
+	}
+
+	timerSvc := NewTimerService(db, timeSvc, 8*time.Hour)
+	if _, err := timerSvc.Start(ctx, TimerInput{UserID: 2, TaskID: task.ID}); err != nil {
+		t.Fatalf("start timer: %v", err)
+	}
+	if _, err := projectSvc.ArchiveProject(ctx, project.ID, 1); !errors.Is(err, ErrProjectHasActiveTimers) {
+		t.Fatalf("expected ErrProjectHasActiveTimers, got %v", err)
+	}
+	if err := timerSvc.Discard(ctx, 2); err != nil {
+		t.Fatalf("discard timer: %v", err)
+	}
+
+	archived, err := projectSvc.ArchiveProject(ctx, project.ID, 1)
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if archived.ArchivedAt == nil || archived.ArchivedBy == nil || *archived.ArchivedBy != 1 {
+		t.Fatalf("expected archive metadata, got %+v", archived)
+	}
+
+	page, err := projectSvc.ListProjects(ctx, ProjectFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if page.Total != 0 {
+		t.Fatalf("expected archived project hidden by default, got %d", page.Total)
+	}
+	page, err = projectSvc.ListProjects(ctx, ProjectFilter{IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if page.Total != 1 {
+		t.Fatalf("expected archived project with includeArchived, got %d", page.Total)
+	}
+
+	if _, err := projectSvc.UpdateProject(ctx, project.ID, ProjectUpdateInput{Name: "Novo", ClientName: "Cliente", Status: ProjectActive, StartDate: start}); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived on update, got %v", err)
+	}
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Outra", AssigneeID: 2}); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived on task creation, got %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 2, EntryDate: start.Add(50 * time.Hour), Hours: 1}); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived on log time, got %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, pending.ID, 1); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived on approval, got %v", err)
+	}
+	if _, err := timeSvc.ReopenEntry(ctx, approved.ID, 1, "ajuste"); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived on reopen, got %v", err)
+	}
+
+	if _, err := projectSvc.UnarchiveProject(ctx, project.ID); err != nil {
+		t.Fatalf("unarchive: %v", err)
+	}
+	if _, err := projectSvc.UnarchiveProject(ctx, project.ID); !errors.Is(err, ErrProjectNotArchived) {
+		t.Fatalf("expected ErrProjectNotArchived, got %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, pending.ID, 1); err != nil {
+		t.Fatalf("approve after unarchive: %v", err)
+	}
+}
+
 func ptrUint(v uint) *uint { return &v }
 
 func ptrTime(v time.Time) *time.Time { return &v }
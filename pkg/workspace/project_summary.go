@@ -0,0 +1,207 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Health labels of ProjectSummary.
+const (
+	HealthGood     = "good"
+	HealthAtRisk   = "at_risk"
+	HealthCritical = "critical"
+)
+
+// ProjectTaskCounts aggregates the tasks of a project. Overdue tasks are not
+// done and were due before today.
+type ProjectTaskCounts struct {
+	Total          int
+	ByStatus       map[TaskStatus]int
+	Overdue        int
+	OverdueTaskIDs []uint
+	Blocked        int
+	BlockedTaskIDs []uint
+	LastUpdatedAt  *time.Time
+}
+
+// ProjectHours aggregates the time entries of a project by review status.
+// LoggedHours counts every entry that was not rejected.
+type ProjectHours struct {
+	LoggedHours   float64
+	ApprovedHours float64
+	PendingHours  float64
+	RejectedHours float64
+	Entries       int
+	LastEntryAt   *time.Time
+}
+
+// ProjectSummary is the health dashboard of a project.
+type ProjectSummary struct {
+	ProjectID      uint
+	Name           string
+	Status         ProjectStatus
+	Tasks          ProjectTaskCounts
+	Hours          ProjectHours
+	LastActivityAt *time.Time
+	DaysToEnd      *int
+	HealthScore    int
+	Health         string
+}
+
+// ProjectTaskCounts counts the tasks of a project per status, plus the
+// overdue and blocked ones, as of now.
+func (s *TaskService) ProjectTaskCounts(ctx context.Context, projectID uint, now time.Time) (*ProjectTaskCounts, error) {
+	db := s.db.WithContext(ctx)
+	counts := &ProjectTaskCounts{ByStatus: map[TaskStatus]int{}}
+
+	var rows []struct {
+		Status TaskStatus
+		Tasks  int
+	}
+	if err := db.Model(&Task{}).
+		Select("status, COUNT(*) AS tasks").
+		Where("project_id = ?", projectID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts.ByStatus[row.Status] = row.Tasks
+		counts.Total += row.Tasks
+	}
+
+	if err := db.Model(&Task{}).
+		Where("project_id = ? AND status <> ? AND due_date < ?", projectID, TaskDone, truncateDay(now)).
+		Order("due_date, id").
+		Pluck("id", &counts.OverdueTaskIDs).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Model(&Task{}).
+		Where("project_id = ? AND status = ?", projectID, TaskBlocked).
+		Order("id").
+		Pluck("id", &counts.BlockedTaskIDs).Error; err != nil {
+		return nil, err
+	}
+	counts.Overdue = len(counts.OverdueTaskIDs)
+	counts.Blocked = len(counts.BlockedTaskIDs)
+
+	var last Task
+	err := db.Select("updated_at").Where("project_id = ?", projectID).Order("updated_at DESC").Take(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		counts.LastUpdatedAt = &last.UpdatedAt
+	}
+	return counts, nil
+}
+
+// ProjectHours sums the hours logged on the tasks of a project per review
+// status.
+func (s *TimeEntryService) ProjectHours(ctx context.Context, projectID uint) (*ProjectHours, error) {
+	db := s.db.WithContext(ctx)
+	projectTasks := db.Session(&gorm.Session{NewDB: true}).
+		Model(&Task{}).Select("id").Where("project_id = ?", projectID)
+
+	var rows []struct {
+		Status  TimeEntryStatus
+		Hours   float64
+		Entries int
+	}
+	if err := db.Model(&TimeEntry{}).
+		Select("status, COALESCE(SUM(hours), 0) AS hours, COUNT(*) AS entries").
+		Where("task_id IN (?)", projectTasks).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	hours := &ProjectHours{}
+	for _, row := range rows {
+		hours.Entries += row.Entries
+		switch row.Status {
+		case TimeEntryApproved:
+			hours.ApprovedHours = roundHours(row.Hours)
+		case TimeEntryRejected:
+			hours.RejectedHours = roundHours(row.Hours)
+		default:
+			hours.PendingHours = roundHours(hours.PendingHours + row.Hours)
+		}
+	}
+	hours.LoggedHours = roundHours(hours.ApprovedHours + hours.PendingHours)
+
+	var last TimeEntry
+	err := db.Select("updated_at").Where("task_id IN (?)", projectTasks).Order("updated_at DESC").Take(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		hours.LastEntryAt = &last.UpdatedAt
+	}
+	return hours, nil
+}
+
+// SummarizeProject builds the dashboard of a project from its task and hour
+// aggregates. The health score starts at 100 and loses up to 40 points for
+// overdue tasks, 20 for blocked tasks, 20 for tasks done behind the elapsed
+// schedule and 20 for hours over the budget.
+func SummarizeProject(project *Project, tasks *ProjectTaskCounts, hours *ProjectHours, now time.Time) *ProjectSummary {
+	summary := &ProjectSummary{
+		ProjectID:      project.ID,
+		Name:           project.Name,
+		Status:         project.Status,
+		Tasks:          *tasks,
+		Hours:          *hours,
+		LastActivityAt: latestTime(&project.UpdatedAt, tasks.LastUpdatedAt, hours.LastEntryAt),
+	}
+	today := truncateDay(now)
+	if project.EndDate != nil {
+		days := int(truncateDay(*project.EndDate).Sub(today) / (24 * time.Hour))
+		summary.DaysToEnd = &days
+	}
+
+	score := 100.0
+	if open := tasks.Total - tasks.ByStatus[TaskDone]; open > 0 {
+		score -= 40 * float64(tasks.Overdue) / float64(open)
+	}
+	if tasks.Total > 0 {
+		score -= 20 * float64(tasks.Blocked) / float64(tasks.Total)
+	}
+	if project.EndDate != nil && tasks.Total > 0 {
+		span := truncateDay(*project.EndDate).Sub(truncateDay(project.StartDate))
+		if span > 0 {
+			elapsed := math.Min(math.Max(float64(today.Sub(truncateDay(project.StartDate)))/float64(span), 0), 1)
+			done := float64(tasks.ByStatus[TaskDone]) / float64(tasks.Total)
+			if elapsed > done {
+				score -= 20 * (elapsed - done)
+			}
+		}
+	}
+	if project.BudgetHours > 0 && hours.LoggedHours > project.BudgetHours {
+		score -= 20 * math.Min(hours.LoggedHours/project.BudgetHours-1, 1)
+	}
+
+	summary.HealthScore = int(math.Round(math.Max(score, 0)))
+	switch {
+	case summary.HealthScore >= 75:
+		summary.Health = HealthGood
+	case summary.HealthScore >= 50:
+		summary.Health = HealthAtRisk
+	default:
+		summary.Health = HealthCritical
+	}
+	return summary
+}
+
+func latestTime(times ...*time.Time) *time.Time {
+	var latest *time.Time
+	for _, t := range times {
+		if t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+	return latest
+}
@@ -0,0 +1,85 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProjectSummary_CountsHoursAndHealth(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	today := truncateDay(now)
+	start := today.AddDate(0, 0, -20)
+	end := today.AddDate(0, 0, 20)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, EndDate: &end, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if project, err = projectSvc.SetBudget(ctx, project.ID, BudgetInput{Hours: 10}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	yesterday := today.AddDate(0, 0, -1)
+	tasks := map[string]*Task{}
+	for _, title := range []string{"done", "blocked", "overdue", "open"} {
+		in := TaskInput{ProjectID: project.ID, Title: title, AssigneeID: 7}
+		if title == "overdue" || title == "done" {
+			in.DueDate = &yesterday
+		}
+		task, err := taskSvc.CreateTask(ctx, in)
+		if err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+		tasks[title] = task
+	}
+	for title, status := range map[string]TaskStatus{"done": TaskDone, "blocked": TaskBlocked} {
+		task := tasks[title]
+		if _, err := taskSvc.UpdateTask(ctx, task.ID, TaskUpdateInput{Title: title, Status: status, AssigneeID: 7, DueDate: task.DueDate}); err != nil {
+			t.Fatalf("update task: %v", err)
+		}
+	}
+
+	approved, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: tasks["open"].ID, UserID: 7, EntryDate: today.AddDate(0, 0, -3), Hours: 6})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, approved.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: tasks["open"].ID, UserID: 7, EntryDate: today.AddDate(0, 0, -2), Hours: 5}); err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	counts, err := taskSvc.ProjectTaskCounts(ctx, project.ID, now)
+	if err != nil {
+		t.Fatalf("task counts: %v", err)
+	}
+	if counts.Total != 4 || counts.ByStatus[TaskTodo] != 2 || counts.Blocked != 1 || counts.Overdue != 1 || counts.OverdueTaskIDs[0] != tasks["overdue"].ID {
+		t.Fatalf("unexpected task counts: %+v", counts)
+	}
+	hours, err := timeSvc.ProjectHours(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("project hours: %v", err)
+	}
+	if hours.LoggedHours != 11 || hours.ApprovedHours != 6 || hours.PendingHours != 5 || hours.Entries != 2 || hours.LastEntryAt == nil {
+		t.Fatalf("unexpected hours: %+v", hours)
+	}
+
+	summary := SummarizeProject(project, counts, hours, now)
+	if summary.DaysToEnd == nil || *summary.DaysToEnd != 20 {
+		t.Fatalf("expected 20 days to end, got %v", summary.DaysToEnd)
+	}
+	// 100 - 40*1/3 overdue - 20*1/4 blocked - 20*(0.5-0.25) schedule - 20*0.1 budget
+	if summary.HealthScore != 75 || summary.Health != HealthGood {
+		t.Fatalf("unexpected health: %d %s", summary.HealthScore, summary.Health)
+	}
+	if summary.LastActivityAt == nil || summary.LastActivityAt.Before(*hours.LastEntryAt) {
+		t.Fatalf("expected last activity to include the latest entry, got %v", summary.LastActivityAt)
+	}
+}
@@ -0,0 +1,292 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProjectTemplateInput names a template saved from a project.
+type ProjectTemplateInput struct {
+	Name        string
+	Description string
+	CreatedBy   uint
+}
+
+// ProjectCopyInput describes the project created from a template or clone.
+// Without CopyAssignees every task is assigned to the new project owner.
+type ProjectCopyInput struct {
+	Project       ProjectInput
+	CopyAssignees bool
+}
+
+// SaveAsTemplate stores the milestones and tasks of a project as a template,
+// with dates turned into offsets from the project start.
+func (s *ProjectService) SaveAsTemplate(ctx context.Context, projectID uint, in ProjectTemplateInput) (*ProjectTemplate, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		return nil, errors.New("template name is required")
+	}
+
+	var template ProjectTemplate
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var project Project
+		if err := tx.First(&project, projectID).Error; err != nil {
+			return err
+		}
+		source, err := blueprintFromProject(tx, &project)
+		if err != nil {
+			return err
+		}
+
+		template = ProjectTemplate{Name: in.Name, Description: in.Description, CreatedBy: in.CreatedBy}
+		if err := tx.Omit(clause.Associations).Create(&template).Error; err != nil {
+			return err
+		}
+		milestoneIDs := make(map[uint]uint, len(source.Milestones))
+		for _, m := range source.Milestones {
+			sourceID := m.ID
+			m.ID = 0
+			m.TemplateID = template.ID
+			if err := tx.Create(&m).Error; err != nil {
+				return err
+			}
+			milestoneIDs[sourceID] = m.ID
+			template.Milestones = append(template.Milestones, m)
+		}
+		taskIDs := make(map[uint]uint, len(source.Tasks))
+		for _, t := range source.Tasks {
+			sourceID := t.ID
+			t.ID = 0
+			t.TemplateID = template.ID
+			t.ParentID = nil
+			if t.MilestoneID != nil {
+				id := milestoneIDs[*t.MilestoneID]
+				t.MilestoneID = &id
+			}
+			if err := tx.Create(&t).Error; err != nil {
+				return err
+			}
+			taskIDs[sourceID] = t.ID
+			template.Tasks = append(template.Tasks, t)
+		}
+		// os pais são ligados depois que todas as tarefas têm ID
+		for i, t := range source.Tasks {
+			if t.ParentID == nil {
+				continue
+			}
+			parentID := taskIDs[*t.ParentID]
+			template.Tasks[i].ParentID = &parentID
+			if err := tx.Model(&template.Tasks[i]).Update("parent_id", parentID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (s *ProjectService) ListTemplates(ctx context.Context) ([]ProjectTemplate, error) {
+	var templates []ProjectTemplate
+	if err := s.db.WithContext(ctx).Order("name, id").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (s *ProjectService) GetTemplate(ctx context.Context, id uint) (*ProjectTemplate, error) {
+	var template ProjectTemplate
+	if err := s.db.WithContext(ctx).
+		Preload("Milestones", func(db *gorm.DB) *gorm.DB { return db.Order("target_offset_days, id") }).
+		Preload("Tasks", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&template, id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (s *ProjectService) DeleteTemplate(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&ProjectTemplate{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&ProjectTemplateTask{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&ProjectTemplateMilestone{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&ProjectTemplate{}, id).Error
+	})
+}
+
+// CreateFromTemplate creates a project with the milestones and tasks of a
+// template, dated from the new start date.
+func (s *ProjectService) CreateFromTemplate(ctx context.Context, templateID uint, in ProjectCopyInput) (*Project, error) {
+	if err := validateProjectInput(in.Project); err != nil {
+		return nil, err
+	}
+
+	var project *Project
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var template ProjectTemplate
+		if err := tx.Preload("Milestones").Preload("Tasks").First(&template, templateID).Error; err != nil {
+			return err
+		}
+		var err error
+		project, err = instantiateBlueprint(tx, &template, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// CloneProject creates a project with copies of the milestones and tasks of
+// another one, keeping their offsets from the start date. Time entries,
+// members and budget are not copied.
+func (s *ProjectService) CloneProject(ctx context.Context, projectID uint, in ProjectCopyInput) (*Project, error) {
+	if err := validateProjectInput(in.Project); err != nil {
+		return nil, err
+	}
+
+	var project *Project
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source Project
+		if err := tx.First(&source, projectID).Error; err != nil {
+			return err
+		}
+		blueprint, err := blueprintFromProject(tx, &source)
+		if err != nil {
+			return err
+		}
+		project, err = instantiateBlueprint(tx, blueprint, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// blueprintFromProject builds an unsaved template from a project. Milestones
+// and tasks keep the IDs of their sources so tasks can refer to them.
+func blueprintFromProject(tx *gorm.DB, project *Project) (*ProjectTemplate, error) {
+	var milestones []Milestone
+	if err := tx.Where("project_id = ?", project.ID).Order("target_date, id").Find(&milestones).Error; err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	if err := tx.Where("project_id = ?", project.ID).Order("id").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	blueprint := &ProjectTemplate{}
+	for _, m := range milestones {
+		blueprint.Milestones = append(blueprint.Milestones, ProjectTemplateMilestone{
+			ID:               m.ID,
+			Name:             m.Name,
+			Description:      m.Description,
+			TargetOffsetDays: offsetDays(project.StartDate, m.TargetDate),
+		})
+	}
+	for _, t := range tasks {
+		item := ProjectTemplateTask{
+			ID:            t.ID,
+			ParentID:      t.ParentID,
+			Title:         t.Title,
+			Description:   t.Description,
+			AssigneeID:    t.AssigneeID,
+			EstimateHours: t.EstimateHours,
+			MilestoneID:   t.MilestoneID,
+		}
+		if t.DueDate != nil {
+			offset := offsetDays(project.StartDate, *t.DueDate)
+			item.DueOffsetDays = &offset
+		}
+		blueprint.Tasks = append(blueprint.Tasks, item)
+	}
+	return blueprint, nil
+}
+
+// instantiateBlueprint creates the project and its planned milestones and
+// to-do tasks, checking the dates against the new project.
+func instantiateBlueprint(tx *gorm.DB, blueprint *ProjectTemplate, in ProjectCopyInput) (*Project, error) {
+	project, err := createProject(tx, in.Project)
+	if err != nil {
+		return nil, err
+	}
+	start := project.StartDate
+
+	milestoneIDs := make(map[uint]uint, len(blueprint.Milestones))
+	for _, m := range blueprint.Milestones {
+		target := start.AddDate(0, 0, m.TargetOffsetDays)
+		if err := validateMilestoneTarget(target, project); err != nil {
+			return nil, err
+		}
+		milestone := Milestone{
+			ProjectID:   project.ID,
+			Name:        m.Name,
+			Description: m.Description,
+			TargetDate:  target,
+			Status:      MilestonePlanned,
+		}
+		if err := tx.Create(&milestone).Error; err != nil {
+			return nil, err
+		}
+		milestoneIDs[m.ID] = milestone.ID
+	}
+
+	taskIDs := make(map[uint]uint, len(blueprint.Tasks))
+	created := make([]Task, len(blueprint.Tasks))
+	for i, t := range blueprint.Tasks {
+		task := Task{
+			ProjectID:     project.ID,
+			Title:         t.Title,
+			Description:   t.Description,
+			Status:        TaskTodo,
+			AssigneeID:    project.OwnerID,
+			EstimateHours: t.EstimateHours,
+		}
+		if in.CopyAssignees {
+			task.AssigneeID = t.AssigneeID
+		}
+		if t.DueOffsetDays != nil {
+			due := start.AddDate(0, 0, *t.DueOffsetDays)
+			if err := validateDueDate(&due, project); err != nil {
+				return nil, err
+			}
+			task.DueDate = &due
+		}
+		if t.MilestoneID != nil {
+			id := milestoneIDs[*t.MilestoneID]
+			task.MilestoneID = &id
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return nil, err
+		}
+		taskIDs[t.ID] = task.ID
+		created[i] = task
+	}
+	for i, t := range blueprint.Tasks {
+		if t.ParentID == nil {
+			continue
+		}
+		if err := tx.Model(&created[i]).Update("parent_id", taskIDs[*t.ParentID]).Error; err != nil {
+			return nil, err
+		}
+	}
+	return project, nil
+}
+
+func offsetDays(start, date time.Time) int {
+	return int(truncateDay(date).Sub(truncateDay(start)) / (24 * time.Hour))
+}
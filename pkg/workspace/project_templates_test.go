@@ -0,0 +1,96 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProjectTemplates_SaveInstantiateAndClone(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC())
+	source, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal ACME", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	milestone, err := projectSvc.CreateMilestone(ctx, source.ID, MilestoneInput{Name: "MVP", TargetDate: start.AddDate(0, 0, 10)})
+	if err != nil {
+		t.Fatalf("create milestone: %v", err)
+	}
+	due := start.AddDate(0, 0, 7)
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: source.ID, Title: "Kickoff", Description: "Reunião inicial", AssigneeID: 7, DueDate: &due, EstimateHours: 2, MilestoneID: &milestone.ID}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: source.ID, Title: "Backlog", AssigneeID: 8}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	template, err := projectSvc.SaveAsTemplate(ctx, source.ID, ProjectTemplateInput{Name: "Portal", CreatedBy: 1})
+	if err != nil {
+		t.Fatalf("save template: %v", err)
+	}
+	if len(template.Milestones) != 1 || template.Milestones[0].TargetOffsetDays != 10 {
+		t.Fatalf("unexpected template milestones: %+v", template.Milestones)
+	}
+	if len(template.Tasks) != 2 || *template.Tasks[0].DueOffsetDays != 7 || *template.Tasks[0].MilestoneID != template.Milestones[0].ID {
+		t.Fatalf("unexpected template tasks: %+v", template.Tasks)
+	}
+
+	newStart := start.AddDate(0, 1, 0)
+	shortEnd := newStart.AddDate(0, 0, 5)
+	if _, err := projectSvc.CreateFromTemplate(ctx, template.ID, ProjectCopyInput{Project: ProjectInput{Name: "Portal Globex", ClientName: "Globex", StartDate: newStart, EndDate: &shortEnd, OwnerID: 2}}); err == nil {
+		t.Fatal("expected dates past the project end to be rejected")
+	}
+	var projects int64
+	db.Model(&Project{}).Where("name = ?", "Portal Globex").Count(&projects)
+	if projects != 0 {
+		t.Fatal("expected the failed copy to be rolled back")
+	}
+
+	fromTemplate, err := projectSvc.CreateFromTemplate(ctx, template.ID, ProjectCopyInput{Project: ProjectInput{Name: "Portal Globex", ClientName: "Globex", StartDate: newStart, OwnerID: 2}})
+	if err != nil {
+		t.Fatalf("create from template: %v", err)
+	}
+	tasks, err := taskSvc.ListTasks(ctx, TaskFilter{ProjectID: fromTemplate.ID})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if tasks.Total != 2 {
+		t.Fatalf("expected 2 copied tasks, got %d", tasks.Total)
+	}
+	for _, task := range tasks.Items {
+		if task.AssigneeID != 2 || task.Status != TaskTodo {
+			t.Fatalf("expected to-do tasks assigned to the new owner, got %+v", task)
+		}
+		if task.Title == "Kickoff" && (task.DueDate == nil || !task.DueDate.Equal(newStart.AddDate(0, 0, 7)) || task.MilestoneID == nil) {
+			t.Fatalf("unexpected kickoff copy: %+v", task)
+		}
+	}
+
+	clone, err := projectSvc.CloneProject(ctx, source.ID, ProjectCopyInput{
+		Project:       ProjectInput{Name: "Portal ACME v2", ClientName: "ACME", StartDate: newStart, OwnerID: 1},
+		CopyAssignees: true,
+	})
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	milestones, err := projectSvc.ListMilestones(ctx, clone.ID)
+	if err != nil {
+		t.Fatalf("list milestones: %v", err)
+	}
+	if len(milestones) != 1 || !milestones[0].TargetDate.Equal(newStart.AddDate(0, 0, 10)) || milestones[0].Progress.TotalTasks != 1 {
+		t.Fatalf("unexpected cloned milestones: %+v", milestones)
+	}
+	assignee := uint(8)
+	cloned, err := taskSvc.ListTasks(ctx, TaskFilter{ProjectID: clone.ID, AssigneeID: &assignee})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if cloned.Total != 1 {
+		t.Fatalf("expected assignees to be copied, got %d tasks for user 8", cloned.Total)
+	}
+}
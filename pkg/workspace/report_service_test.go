@@ -0,0 +1,142 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReportService_HoursReport(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	reportSvc := NewReportService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().AddDate(0, 0, -5)
+	alpha, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Alpha", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	beta, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Beta", ClientName: "Globex", StartDate: start, OwnerID: 2})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	alphaTask, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: alpha.ID, Title: "A1", AssigneeID: 10})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	betaTask, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: beta.ID, Title: "B1", AssigneeID: 11})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	logs := []struct {
+		task   uint
+		user   uint
+		hours  float64
+		reject bool
+	}{
+		{alphaTask.ID, 10, 2, false},
+		{alphaTask.ID, 10, 3, false},
+		{alphaTask.ID, 11, 1.5, false},
+		{betaTask.ID, 11, 4, false},
+		{betaTask.ID, 11, 8, true},
+	}
+	for i, l := range logs {
+		entry, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: l.task, UserID: l.user, EntryDate: start.Add(time.Duration(i+1) * time.Hour), Hours: l.hours})
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+		if l.reject {
+			if _, err := timeSvc.RejectEntry(ctx, entry.ID, 1, "duplicado"); err != nil {
+				t.Fatalf("reject: %v", err)
+			}
+		}
+	}
+
+	report, err := reportSvc.HoursReport(ctx, HoursReportFilter{GroupBy: []ReportDimension{ReportByProject, ReportByUser}})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.TotalHours != 10.5 || report.Entries != 4 {
+		t.Fatalf("rejected entries must be excluded, got %+v", report)
+	}
+	if len(report.Rows) != 3 || *report.Rows[0].ProjectID != alpha.ID || *report.Rows[0].UserID != 10 || report.Rows[0].Hours != 5 {
+		t.Fatalf("unexpected rows: %+v", report.Rows)
+	}
+
+	// dono do projeto Alpha vê o projeto inteiro; usuário 11 só os próprios
+	owner := uint(1)
+	report, err = reportSvc.HoursReport(ctx, HoursReportFilter{GroupBy: []ReportDimension{ReportByClient}, VisibleTo: &owner})
+	if err != nil {
+		t.Fatalf("owner report: %v", err)
+	}
+	if len(report.Rows) != 1 || *report.Rows[0].Client != "ACME" || report.TotalHours != 6.5 {
+		t.Fatalf("unexpected owner scope: %+v", report.Rows)
+	}
+	member := uint(11)
+	report, err = reportSvc.HoursReport(ctx, HoursReportFilter{GroupBy: []ReportDimension{ReportByMonth}, VisibleTo: &member})
+	if err != nil {
+		t.Fatalf("member report: %v", err)
+	}
+	if report.TotalHours != 5.5 || report.Rows[0].Period == nil {
+		t.Fatalf("unexpected member scope: %+v", report)
+	}
+
+	// semanas ISO nas viradas de ano, com datas gravadas direto no banco
+	for _, date := range []time.Time{
+		time.Date(2021, 1, 3, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 30, 1, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 17, 12, 0, 0, 0, time.UTC),
+	} {
+		if err := db.Create(&TimeEntry{TaskID: alphaTask.ID, UserID: 12, EntryDate: date, Hours: 1, Status: TimeEntryApproved}).Error; err != nil {
+			t.Fatalf("create entry: %v", err)
+		}
+	}
+	backfill := uint(12)
+	for dim, want := range map[ReportDimension][]string{
+		ReportByDay:   {"2021-01-03", "2024-12-30", "2026-06-17"},
+		ReportByWeek:  {"2020-W53", "2025-W01", "2026-W25"},
+		ReportByMonth: {"2021-01", "2024-12", "2026-06"},
+	} {
+		report, err := reportSvc.HoursReport(ctx, HoursReportFilter{GroupBy: []ReportDimension{dim}, UserID: &backfill})
+		if err != nil {
+			t.Fatalf("%s report: %v", dim, err)
+		}
+		if len(report.Rows) != len(want) || report.Entries != 3 {
+			t.Fatalf("unexpected %s rows: %+v", dim, report.Rows)
+		}
+		for i, row := range report.Rows {
+			if row.Period == nil || *row.Period != want[i] || row.Hours != 1 {
+				t.Fatalf("expected %s period %s, got %+v", dim, want[i], row)
+			}
+		}
+	}
+
+	// membros gestores veem o projeto como o dono; demais papéis não
+	if _, err := projectSvc.AddMember(ctx, beta.ID, 20, ProjectManager, 2); err != nil {
+		t.Fatalf("add manager: %v", err)
+	}
+	if _, err := projectSvc.AddMember(ctx, beta.ID, 21, ProjectViewer, 2); err != nil {
+		t.Fatalf("add viewer: %v", err)
+	}
+	for viewer, want := range map[uint]float64{20: 4, 21: 0} {
+		report, err := reportSvc.HoursReport(ctx, HoursReportFilter{VisibleTo: &viewer})
+		if err != nil {
+			t.Fatalf("member report: %v", err)
+		}
+		if report.TotalHours != want {
+			t.Fatalf("expected user %d to see %.1f hours, got %+v", viewer, want, report)
+		}
+	}
+
+	if _, err := reportSvc.HoursReport(ctx, HoursReportFilter{Status: []TimeEntryStatus{"late"}}); !errors.Is(err, ErrInvalidTimeEntryStatus) || !errors.Is(err, ErrInvalidReportFilter) {
+		t.Fatalf("expected an invalid status to be rejected, got %v", err)
+	}
+	if _, err := reportSvc.HoursReport(ctx, HoursReportFilter{GroupBy: []ReportDimension{ReportByDay, ReportByWeek}}); !errors.Is(err, ErrInvalidReportFilter) {
+		t.Fatal("expected error for two period dimensions")
+	}
+}
@@ -0,0 +1,243 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTagNameTaken is returned when another tag already uses the name.
+var ErrTagNameTaken = errors.New("a tag with this name already exists")
+
+// TagInput holds tag data. Color is an optional #rrggbb value.
+type TagInput struct {
+	Name  string
+	Color string
+}
+
+// TagService manages the tag catalog and the tags of projects and tasks.
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService(db *gorm.DB) *TagService {
+	return &TagService{db: db}
+}
+
+func (s *TagService) CreateTag(ctx context.Context, in TagInput) (*Tag, error) {
+	in, err := normalizeTagInput(in)
+	if err != nil {
+		return nil, err
+	}
+	tag := &Tag{Name: in.Name, Color: in.Color}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureTagNameFree(tx, in.Name, 0); err != nil {
+			return err
+		}
+		return tx.Create(tag).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+func (s *TagService) UpdateTag(ctx context.Context, id uint, in TagInput) (*Tag, error) {
+	in, err := normalizeTagInput(in)
+	if err != nil {
+		return nil, err
+	}
+	var tag Tag
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&tag, id).Error; err != nil {
+			return err
+		}
+		if err := ensureTagNameFree(tx, in.Name, id); err != nil {
+			return err
+		}
+		tag.Name = in.Name
+		tag.Color = in.Color
+		return tx.Save(&tag).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (s *TagService) ListTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+	if err := s.db.WithContext(ctx).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// DeleteTag removes a tag from the catalog and from every project and task.
+func (s *TagService) DeleteTag(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&Tag{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&ProjectTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&TaskTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Tag{}, id).Error
+	})
+}
+
+// TagProject adds a tag to a project; adding it twice is a no-op.
+func (s *TagService) TagProject(ctx context.Context, projectID, tagID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureProjectTaggable(tx, projectID); err != nil {
+			return err
+		}
+		if err := tx.First(&Tag{}, tagID).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&ProjectTag{ProjectID: projectID, TagID: tagID}).Error
+	})
+}
+
+func (s *TagService) UntagProject(ctx context.Context, projectID, tagID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureProjectTaggable(tx, projectID); err != nil {
+			return err
+		}
+		return tx.Where("project_id = ? AND tag_id = ?", projectID, tagID).Delete(&ProjectTag{}).Error
+	})
+}
+
+func (s *TagService) ProjectTags(ctx context.Context, projectID uint) ([]Tag, error) {
+	var tags []Tag
+	if err := s.db.WithContext(ctx).
+		Joins("JOIN project_tags ON project_tags.tag_id = tags.id").
+		Where("project_tags.project_id = ?", projectID).
+		Order("tags.name").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// TagTask adds a tag to a task; adding it twice is a no-op.
+func (s *TagService) TagTask(ctx context.Context, taskID, tagID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureTaskTaggable(tx, taskID); err != nil {
+			return err
+		}
+		if err := tx.First(&Tag{}, tagID).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&TaskTag{TaskID: taskID, TagID: tagID}).Error
+	})
+}
+
+func (s *TagService) UntagTask(ctx context.Context, taskID, tagID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureTaskTaggable(tx, taskID); err != nil {
+			return err
+		}
+		return tx.Where("task_id = ? AND tag_id = ?", taskID, tagID).Delete(&TaskTag{}).Error
+	})
+}
+
+func (s *TagService) TaskTags(ctx context.Context, taskID uint) ([]Tag, error) {
+	var tags []Tag
+	if err := s.db.WithContext(ctx).
+		Joins("JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("task_tags.task_id = ?", taskID).
+		Order("tags.name").
+		Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// taggedProjects selects the IDs of the projects with the named tag.
+func taggedProjects(tx *gorm.DB, name string) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Table("project_tags").
+		Select("project_tags.project_id").
+		Joins("JOIN tags ON tags.id = project_tags.tag_id").
+		Where("tags.name = ?", normalizeTagName(name))
+}
+
+// taggedTasks selects the IDs of the tasks with the named tag.
+func taggedTasks(tx *gorm.DB, name string) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Table("task_tags").
+		Select("task_tags.task_id").
+		Joins("JOIN tags ON tags.id = task_tags.tag_id").
+		Where("tags.name = ?", normalizeTagName(name))
+}
+
+func ensureProjectTaggable(tx *gorm.DB, projectID uint) error {
+	var project Project
+	if err := tx.First(&project, projectID).Error; err != nil {
+		return err
+	}
+	if project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+func ensureTaskTaggable(tx *gorm.DB, taskID uint) error {
+	var task Task
+	if err := tx.Preload("Project").First(&task, taskID).Error; err != nil {
+		return err
+	}
+	if task.Project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+func ensureTagNameFree(tx *gorm.DB, name string, exceptID uint) error {
+	var count int64
+	q := tx.Model(&Tag{}).Where("name = ?", name)
+	if exceptID != 0 {
+		q = q.Where("id <> ?", exceptID)
+	}
+	if err := q.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTagNameTaken
+	}
+	return nil
+}
+
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func normalizeTagInput(in TagInput) (TagInput, error) {
+	in.Name = normalizeTagName(in.Name)
+	if in.Name == "" {
+		return in, errors.New("tag name is required")
+	}
+	if len(in.Name) > 50 {
+		return in, errors.New("tag name must have at most 50 characters")
+	}
+	in.Color = strings.ToLower(strings.TrimSpace(in.Color))
+	if in.Color != "" {
+		if len(in.Color) != 7 || in.Color[0] != '#' {
+			return in, errors.New("color must be a #rrggbb value")
+		}
+		if _, err := strconv.ParseUint(in.Color[1:], 16, 32); err != nil {
+			return in, errors.New("color must be a #rrggbb value")
+		}
+	}
+	return in, nil
+}
@@ -0,0 +1,121 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTagService_FiltersProjectsTasksAndReports(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	tagSvc := NewTagService(db)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	reportSvc := NewReportService(db)
+	ctx := context.Background()
+
+	maintenance, err := tagSvc.CreateTag(ctx, TagInput{Name: " Maintenance ", Color: "#FFAA00"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+	if maintenance.Name != "maintenance" || maintenance.Color != "#ffaa00" {
+		t.Fatalf("expected normalized tag, got %+v", maintenance)
+	}
+	if _, err := tagSvc.CreateTag(ctx, TagInput{Name: "MAINTENANCE"}); !errors.Is(err, ErrTagNameTaken) {
+		t.Fatalf("expected ErrTagNameTaken, got %v", err)
+	}
+	if _, err := tagSvc.CreateTag(ctx, TagInput{Name: "feature", Color: "red"}); err == nil {
+		t.Fatal("expected invalid color to be rejected")
+	}
+	feature, err := tagSvc.CreateTag(ctx, TagInput{Name: "feature"})
+	if err != nil {
+		t.Fatalf("create tag: %v", err)
+	}
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	support, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Suporte", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	portal, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	ticket, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: support.ID, Title: "Chamados", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	login, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	hotfix, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: portal.ID, Title: "Hotfix", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	if err := tagSvc.TagProject(ctx, support.ID, maintenance.ID); err != nil {
+		t.Fatalf("tag project: %v", err)
+	}
+	if err := tagSvc.TagProject(ctx, support.ID, maintenance.ID); err != nil {
+		t.Fatalf("tagging twice should be a no-op: %v", err)
+	}
+	if err := tagSvc.TagTask(ctx, login.ID, feature.ID); err != nil {
+		t.Fatalf("tag task: %v", err)
+	}
+	if err := tagSvc.TagTask(ctx, hotfix.ID, maintenance.ID); err != nil {
+		t.Fatalf("tag task: %v", err)
+	}
+
+	projects, err := projectSvc.ListProjects(ctx, ProjectFilter{Tags: []string{"Maintenance"}})
+	if err != nil {
+		t.Fatalf("list projects: %v", err)
+	}
+	if projects.Total != 1 || projects.Items[0].ID != support.ID {
+		t.Fatalf("expected only the support project, got %+v", projects.Items)
+	}
+	tasks, err := taskSvc.ListTasks(ctx, TaskFilter{Tags: []string{"maintenance"}})
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	if tasks.Total != 2 {
+		t.Fatalf("expected the support task and the tagged hotfix, got %d tasks", tasks.Total)
+	}
+
+	for _, entry := range []TimeEntryInput{
+		{TaskID: ticket.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 3},
+		{TaskID: login.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 5},
+		{TaskID: hotfix.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2), Hours: 1.5},
+	} {
+		if _, err := timeSvc.LogTime(ctx, entry); err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+	}
+	report, err := reportSvc.HoursReport(ctx, HoursReportFilter{Tags: []string{"maintenance"}})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.TotalHours != 4.5 || report.Entries != 2 {
+		t.Fatalf("expected 4.5 maintenance hours, got %+v", report)
+	}
+	report, err = reportSvc.HoursReport(ctx, HoursReportFilter{Tags: []string{"feature"}})
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report.TotalHours != 5 {
+		t.Fatalf("expected 5 feature hours, got %+v", report)
+	}
+
+	if err := tagSvc.DeleteTag(ctx, maintenance.ID); err != nil {
+		t.Fatalf("delete tag: %v", err)
+	}
+	tags, err := tagSvc.ProjectTags(ctx, support.ID)
+	if err != nil {
+		t.Fatalf("project tags: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Fatalf("expected deleted tag to be unlinked, got %+v", tags)
+	}
+}
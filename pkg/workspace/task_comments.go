@@ -0,0 +1,167 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const maxCommentLength = 2000
+
+// TaskCommentFilter holds list parameters of a comment thread.
+type TaskCommentFilter struct {
+	TaskID   uint
+	Page     int
+	PageSize int
+}
+
+// TaskCommentsPage wraps a paginated comment thread, oldest first.
+type TaskCommentsPage struct {
+	Items []TaskComment
+	Total int64
+}
+
+// AddComment posts a comment on a task.
+func (s *TaskService) AddComment(ctx context.Context, taskID, authorID uint, body string) (*TaskComment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureTaskWritable(ctx, taskID); err != nil {
+		return nil, err
+	}
+	comment := TaskComment{TaskID: taskID, AuthorID: authorID, Body: body}
+	if err := s.db.WithContext(ctx).Create(&comment).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListComments returns a page of the comments of a task, skipping deleted
+// ones.
+func (s *TaskService) ListComments(ctx context.Context, filter TaskCommentFilter) (TaskCommentsPage, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	tx := s.db.WithContext(ctx).Model(&TaskComment{}).Where("task_id = ?", filter.TaskID)
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return TaskCommentsPage{}, err
+	}
+	var comments []TaskComment
+	if err := tx.Order("created_at, id").
+		Limit(filter.PageSize).
+		Offset((filter.Page - 1) * filter.PageSize).
+		Find(&comments).Error; err != nil {
+		return TaskCommentsPage{}, err
+	}
+	return TaskCommentsPage{Items: comments, Total: total}, nil
+}
+
+// GetComment fetches a comment of a task.
+func (s *TaskService) GetComment(ctx context.Context, taskID, commentID uint) (*TaskComment, error) {
+	var comment TaskComment
+	if err := s.db.WithContext(ctx).Where("task_id = ?", taskID).First(&comment, commentID).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateComment replaces the body of a comment, keeping the previous one in
+// its edit history.
+func (s *TaskService) UpdateComment(ctx context.Context, taskID, commentID, editorID uint, body string) (*TaskComment, error) {
+	body, err := normalizeCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureTaskWritable(ctx, taskID); err != nil {
+		return nil, err
+	}
+	var comment TaskComment
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("task_id = ?", taskID).First(&comment, commentID).Error; err != nil {
+			return err
+		}
+		if comment.Body == body {
+			return nil
+		}
+		revision := TaskCommentRevision{CommentID: comment.ID, Body: comment.Body, EditedBy: editorID}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		comment.Body = body
+		comment.EditedAt = &now
+		return tx.Save(&comment).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// DeleteComment soft deletes a comment; its history is kept.
+func (s *TaskService) DeleteComment(ctx context.Context, taskID, commentID, deletedBy uint) error {
+	if err := s.ensureTaskWritable(ctx, taskID); err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment TaskComment
+		if err := tx.Where("task_id = ?", taskID).First(&comment, commentID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&comment).Update("deleted_by", deletedBy).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&comment).Error
+	})
+}
+
+// CommentHistory returns the previous bodies of a comment, newest first.
+func (s *TaskService) CommentHistory(ctx context.Context, taskID, commentID uint) ([]TaskCommentRevision, error) {
+	if _, err := s.GetComment(ctx, taskID, commentID); err != nil {
+		return nil, err
+	}
+	var revisions []TaskCommentRevision
+	if err := s.db.WithContext(ctx).
+		Where("comment_id = ?", commentID).
+		Order("created_at DESC, id DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// ensureTaskWritable rejects changes to the comments of tasks in archived
+// projects.
+func (s *TaskService) ensureTaskWritable(ctx context.Context, taskID uint) error {
+	var task Task
+	if err := s.db.WithContext(ctx).Preload("Project").First(&task, taskID).Error; err != nil {
+		return err
+	}
+	if task.Project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+func normalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment body is required")
+	}
+	if len([]rune(body)) > maxCommentLength {
+		return "", errors.New("comment body is too long")
+	}
+	return body, nil
+}
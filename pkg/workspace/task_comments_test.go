@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestTaskComments_ThreadEditsAndSoftDelete(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	if _, err := taskSvc.AddComment(ctx, task.ID, 7, "   "); err == nil {
+		t.Fatal("expected an empty comment to be rejected")
+	}
+	if _, err := taskSvc.AddComment(ctx, task.ID, 7, strings.Repeat("a", maxCommentLength+1)); err == nil {
+		t.Fatal("expected a long comment to be rejected")
+	}
+	first, err := taskSvc.AddComment(ctx, task.ID, 7, " Começando hoje ")
+	if err != nil {
+		t.Fatalf("add comment: %v", err)
+	}
+	if first.Body != "Começando hoje" {
+		t.Fatalf("expected trimmed body, got %q", first.Body)
+	}
+	for _, body := range []string{"Bloqueado pela API", "Liberado"} {
+		if _, err := taskSvc.AddComment(ctx, task.ID, 1, body); err != nil {
+			t.Fatalf("add comment: %v", err)
+		}
+	}
+
+	page, err := taskSvc.ListComments(ctx, TaskCommentFilter{TaskID: task.ID, Page: 1, PageSize: 2})
+	if err != nil {
+		t.Fatalf("list comments: %v", err)
+	}
+	if page.Total != 3 || len(page.Items) != 2 || page.Items[0].ID != first.ID {
+		t.Fatalf("expected the oldest two of three comments, got %+v", page)
+	}
+
+	edited, err := taskSvc.UpdateComment(ctx, task.ID, first.ID, 7, "Começando amanhã")
+	if err != nil {
+		t.Fatalf("update comment: %v", err)
+	}
+	if edited.EditedAt == nil || edited.Body != "Começando amanhã" {
+		t.Fatalf("expected edited comment, got %+v", edited)
+	}
+	if _, err := taskSvc.UpdateComment(ctx, task.ID+1, first.ID, 7, "outra tarefa"); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected comment of another task to be not found, got %v", err)
+	}
+	history, err := taskSvc.CommentHistory(ctx, task.ID, first.ID)
+	if err != nil {
+		t.Fatalf("comment history: %v", err)
+	}
+	if len(history) != 1 || history[0].Body != "Começando hoje" || history[0].EditedBy != 7 {
+		t.Fatalf("expected the original body in the history, got %+v", history)
+	}
+
+	if err := taskSvc.DeleteComment(ctx, task.ID, first.ID, 1); err != nil {
+		t.Fatalf("delete comment: %v", err)
+	}
+	if _, err := taskSvc.GetComment(ctx, task.ID, first.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected deleted comment to be hidden, got %v", err)
+	}
+	var deleted TaskComment
+	if err := db.Unscoped().First(&deleted, first.ID).Error; err != nil || deleted.DeletedBy == nil || *deleted.DeletedBy != 1 {
+		t.Fatalf("expected comment to be soft deleted by user 1, got %+v (%v)", deleted, err)
+	}
+	if page, _ = taskSvc.ListComments(ctx, TaskCommentFilter{TaskID: task.ID}); page.Total != 2 {
+		t.Fatalf("expected two comments left, got %d", page.Total)
+	}
+
+	if _, err := projectSvc.ArchiveProject(ctx, project.ID, 1); err != nil {
+		t.Fatalf("archive project: %v", err)
+	}
+	if _, err := taskSvc.AddComment(ctx, task.ID, 7, "Depois do arquivo"); !errors.Is(err, ErrProjectArchived) {
+		t.Fatalf("expected ErrProjectArchived, got %v", err)
+	}
+}
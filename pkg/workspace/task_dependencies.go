@@ -0,0 +1,148 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTaskBlockedByPredecessors prevents starting or finishing a task while
+// one of its predecessors is not done.
+var ErrTaskBlockedByPredecessors = errors.New("task is blocked by unfinished predecessors")
+
+// TaskDependencies describes the dependency graph around a task. BlockedBy
+// lists the predecessors that are not done yet.
+type TaskDependencies struct {
+	TaskID       uint
+	Predecessors []Task
+	Successors   []Task
+	BlockedBy    []uint
+	Blocked      bool
+}
+
+// AddDependency makes the task wait for the predecessor. Adding an existing
+// dependency is a no-op; dependencies that would close a cycle are rejected.
+func (s *TaskService) AddDependency(ctx context.Context, taskID, predecessorID uint) error {
+	if taskID == predecessorID {
+		return errors.New("a task cannot depend on itself")
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var task Task
+		if err := tx.Preload("Project").First(&task, taskID).Error; err != nil {
+			return err
+		}
+		if task.Project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		var predecessor Task
+		err := tx.First(&predecessor, predecessorID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && predecessor.ProjectID != task.ProjectID) {
+			return fmt.Errorf("predecessor task %d not found in project", predecessorID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := ensureNoDependencyCycle(tx, taskID, predecessorID); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&TaskDependency{TaskID: taskID, PredecessorID: predecessorID}).Error
+	})
+}
+
+// RemoveDependency drops the link between the task and the predecessor.
+func (s *TaskService) RemoveDependency(ctx context.Context, taskID, predecessorID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var task Task
+		if err := tx.Preload("Project").First(&task, taskID).Error; err != nil {
+			return err
+		}
+		if task.Project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		res := tx.Where("task_id = ? AND predecessor_id = ?", taskID, predecessorID).Delete(&TaskDependency{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// Dependencies returns the predecessors and successors of a task and whether
+// it is currently blocked by any of them.
+func (s *TaskService) Dependencies(ctx context.Context, taskID uint) (*TaskDependencies, error) {
+	db := s.db.WithContext(ctx)
+	if err := db.First(&Task{}, taskID).Error; err != nil {
+		return nil, err
+	}
+
+	deps := &TaskDependencies{TaskID: taskID}
+	if err := db.Where("id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+		Model(&TaskDependency{}).Select("predecessor_id").Where("task_id = ?", taskID)).
+		Order("id").
+		Find(&deps.Predecessors).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+		Model(&TaskDependency{}).Select("task_id").Where("predecessor_id = ?", taskID)).
+		Order("id").
+		Find(&deps.Successors).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range deps.Predecessors {
+		if p.Status != TaskDone {
+			deps.BlockedBy = append(deps.BlockedBy, p.ID)
+		}
+	}
+	deps.Blocked = len(deps.BlockedBy) > 0
+	return deps, nil
+}
+
+// ensureNoDependencyCycle walks the predecessors of predecessorID; reaching
+// taskID means the new link would close a cycle.
+func ensureNoDependencyCycle(tx *gorm.DB, taskID, predecessorID uint) error {
+	seen := map[uint]bool{predecessorID: true}
+	frontier := []uint{predecessorID}
+	for len(frontier) > 0 {
+		var next []uint
+		if err := tx.Model(&TaskDependency{}).
+			Where("task_id IN ?", frontier).
+			Pluck("predecessor_id", &next).Error; err != nil {
+			return err
+		}
+		frontier = frontier[:0]
+		for _, id := range next {
+			if id == taskID {
+				return fmt.Errorf("task %d already depends on task %d; the dependency would create a cycle", predecessorID, taskID)
+			}
+			if !seen[id] {
+				seen[id] = true
+				frontier = append(frontier, id)
+			}
+		}
+	}
+	return nil
+}
+
+// ensurePredecessorsDone rejects starting or finishing a task while any of
+// its predecessors is open.
+func ensurePredecessorsDone(tx *gorm.DB, taskID uint) error {
+	var open []uint
+	if err := tx.Model(&Task{}).
+		Where("id IN (?) AND status <> ?", tx.Session(&gorm.Session{NewDB: true}).
+			Model(&TaskDependency{}).Select("predecessor_id").Where("task_id = ?", taskID), TaskDone).
+		Order("id").
+		Pluck("id", &open).Error; err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: %v", ErrTaskBlockedByPredecessors, open)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskDependencies_CyclesAndBlockedTransitions(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	other, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Suporte", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	tasks := map[string]*Task{}
+	for _, title := range []string{"design", "build", "deploy"} {
+		task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: title, AssigneeID: 7})
+		if err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+		tasks[title] = task
+	}
+	foreign, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: other.ID, Title: "Chamado", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	if err := taskSvc.AddDependency(ctx, tasks["build"].ID, tasks["design"].ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if err := taskSvc.AddDependency(ctx, tasks["build"].ID, tasks["design"].ID); err != nil {
+		t.Fatalf("adding twice should be a no-op: %v", err)
+	}
+	if err := taskSvc.AddDependency(ctx, tasks["deploy"].ID, tasks["build"].ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if err := taskSvc.AddDependency(ctx, tasks["design"].ID, tasks["deploy"].ID); err == nil {
+		t.Fatal("expected a cycle through build to be rejected")
+	}
+	if err := taskSvc.AddDependency(ctx, tasks["design"].ID, tasks["design"].ID); err == nil {
+		t.Fatal("expected a self dependency to be rejected")
+	}
+	if err := taskSvc.AddDependency(ctx, tasks["deploy"].ID, foreign.ID); err == nil {
+		t.Fatal("expected a predecessor from another project to be rejected")
+	}
+
+	deps, err := taskSvc.Dependencies(ctx, tasks["build"].ID)
+	if err != nil {
+		t.Fatalf("dependencies: %v", err)
+	}
+	if !deps.Blocked || len(deps.BlockedBy) != 1 || deps.BlockedBy[0] != tasks["design"].ID || len(deps.Successors) != 1 {
+		t.Fatalf("expected build blocked by design, got %+v", deps)
+	}
+
+	build := tasks["build"]
+	if _, err := taskSvc.UpdateTask(ctx, build.ID, TaskUpdateInput{Title: build.Title, Status: TaskInProgress, AssigneeID: 7}); !errors.Is(err, ErrTaskBlockedByPredecessors) {
+		t.Fatalf("expected ErrTaskBlockedByPredecessors, got %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, build.ID, TaskUpdateInput{Title: "Build renomeado", Status: TaskTodo, AssigneeID: 7}); err != nil {
+		t.Fatalf("editing without starting should be allowed: %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, build.ID, TaskUpdateInput{Title: build.Title, Status: TaskInProgress, AssigneeID: 7, OverrideDependencies: true}); err != nil {
+		t.Fatalf("override should allow starting: %v", err)
+	}
+
+	design := tasks["design"]
+	if _, err := taskSvc.UpdateTask(ctx, design.ID, TaskUpdateInput{Title: design.Title, Status: TaskDone, AssigneeID: 7}); err != nil {
+		t.Fatalf("finish design: %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, build.ID, TaskUpdateInput{Title: build.Title, Status: TaskDone, AssigneeID: 7}); err != nil {
+		t.Fatalf("finish build after design: %v", err)
+	}
+	if deps, _ = taskSvc.Dependencies(ctx, tasks["deploy"].ID); deps.Blocked {
+		t.Fatalf("expected deploy to be unblocked, got %+v", deps)
+	}
+
+	if err := taskSvc.RemoveDependency(ctx, tasks["deploy"].ID, tasks["build"].ID); err != nil {
+		t.Fatalf("remove dependency: %v", err)
+	}
+	if err := taskSvc.RemoveDependency(ctx, tasks["deploy"].ID, tasks["build"].ID); err == nil {
+		t.Fatal("expected removing a missing dependency to fail")
+	}
+}
@@ -0,0 +1,182 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"gorm.io/gorm"
+)
+
+// ErrTaskHasOpenSubtasks prevents finishing a task whose subtasks are not
+// done yet.
+var ErrTaskHasOpenSubtasks = errors.New("task has subtasks that are not done")
+
+// TaskRollup aggregates the status and logged hours of every subtask of a
+// task, at any depth. Hours exclude rejected entries.
+type TaskRollup struct {
+	TaskID           uint
+	Status           TaskStatus
+	Subtasks         int
+	SubtasksByStatus map[TaskStatus]int
+	DoneSubtasks     int
+	PercentDone      float64
+	OwnHours         float64
+	SubtaskHours     float64
+	TotalHours       float64
+}
+
+// ListChildren returns the direct subtasks of a task.
+func (s *TaskService) ListChildren(ctx context.Context, id uint) ([]Task, error) {
+	if err := s.db.WithContext(ctx).First(&Task{}, id).Error; err != nil {
+		return nil, err
+	}
+	var children []Task
+	if err := s.db.WithContext(ctx).
+		Where("parent_id = ?", id).
+		Order("id").
+		Find(&children).Error; err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// MoveTask puts a task under another task of the same project, or at the top
+// level when parentID is nil.
+func (s *TaskService) MoveTask(ctx context.Context, id uint, parentID *uint) (*Task, error) {
+	var task Task
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Project").First(&task, id).Error; err != nil {
+			return err
+		}
+		if task.Project.ArchivedAt != nil {
+			return ErrProjectArchived
+		}
+		if err := validateTaskParent(tx, &task, parentID); err != nil {
+			return err
+		}
+		task.ParentID = parentID
+		return tx.Model(&task).Update("parent_id", parentID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Rollup aggregates the subtasks of a task from one query for the tasks and
+// one for the hours of its project.
+func (s *TaskService) Rollup(ctx context.Context, id uint) (*TaskRollup, error) {
+	db := s.db.WithContext(ctx)
+	var task Task
+	if err := db.First(&task, id).Error; err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	if err := db.Select("id, parent_id, status").
+		Where("project_id = ?", task.ProjectID).
+		Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	var hours []struct {
+		TaskID uint
+		Hours  float64
+	}
+	if err := db.Model(&TimeEntry{}).
+		Select("task_id, COALESCE(SUM(hours), 0) AS hours").
+		Where("task_id IN (?)", db.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id = ?", task.ProjectID)).
+		Where("status <> ?", TimeEntryRejected).
+		Group("task_id").
+		Scan(&hours).Error; err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]Task, len(tasks))
+	for _, t := range tasks {
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
+	hoursByTask := make(map[uint]float64, len(hours))
+	for _, h := range hours {
+		hoursByTask[h.TaskID] = h.Hours
+	}
+
+	rollup := &TaskRollup{
+		TaskID:           task.ID,
+		Status:           task.Status,
+		SubtasksByStatus: map[TaskStatus]int{},
+		OwnHours:         roundHours(hoursByTask[task.ID]),
+	}
+	queue := append([]Task(nil), children[task.ID]...)
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = append(queue[1:], children[child.ID]...)
+		rollup.Subtasks++
+		rollup.SubtasksByStatus[child.Status]++
+		if child.Status == TaskDone {
+			rollup.DoneSubtasks++
+		}
+		rollup.SubtaskHours += hoursByTask[child.ID]
+	}
+	rollup.SubtaskHours = roundHours(rollup.SubtaskHours)
+	rollup.TotalHours = roundHours(rollup.OwnHours + rollup.SubtaskHours)
+	if rollup.Subtasks > 0 {
+		rollup.PercentDone = math.Round(float64(rollup.DoneSubtasks)/float64(rollup.Subtasks)*10000) / 100
+	}
+	return rollup, nil
+}
+
+// validateTaskParent checks that parentID is another open task of the same
+// project and not one of the task's own subtasks. task.ID is zero for new
+// tasks.
+func validateTaskParent(tx *gorm.DB, task *Task, parentID *uint) error {
+	if parentID == nil {
+		return nil
+	}
+	var parent Task
+	err := tx.First(&parent, *parentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && parent.ProjectID != task.ProjectID) {
+		return fmt.Errorf("parent task %d not found in project", *parentID)
+	}
+	if err != nil {
+		return err
+	}
+	if parent.Status == TaskDone && task.Status != TaskDone {
+		return errors.New("parent task is done; reopen it before adding open subtasks")
+	}
+
+	// sobe a partir do novo pai; encontrar a própria tarefa indicaria um ciclo
+	for ancestor := &parent; task.ID != 0; {
+		if ancestor.ID == task.ID {
+			return errors.New("a task cannot be moved under itself or one of its subtasks")
+		}
+		if ancestor.ParentID == nil {
+			break
+		}
+		var next Task
+		if err := tx.Select("id, parent_id").First(&next, *ancestor.ParentID).Error; err != nil {
+			return err
+		}
+		ancestor = &next
+	}
+	return nil
+}
+
+// ensureSubtasksDone rejects finishing a task with open direct subtasks; the
+// same rule on each level covers the whole hierarchy.
+func ensureSubtasksDone(tx *gorm.DB, taskID uint) error {
+	var open int64
+	if err := tx.Model(&Task{}).
+		Where("parent_id = ? AND status <> ?", taskID, TaskDone).
+		Count(&open).Error; err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("%w: %d open", ErrTaskHasOpenSubtasks, open)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskHierarchy_NestingMovesAndRollup(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := truncateDay(time.Now().UTC().AddDate(0, 0, -10))
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Portal", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	other, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Suporte", ClientName: "ACME", StartDate: start, OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	epic, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Login", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	story, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Formulário", AssigneeID: 7, ParentID: &epic.ID})
+	if err != nil {
+		t.Fatalf("create subtask: %v", err)
+	}
+	step, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Validação", AssigneeID: 7, ParentID: &story.ID})
+	if err != nil {
+		t.Fatalf("create nested subtask: %v", err)
+	}
+	if _, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: other.ID, Title: "Chamado", AssigneeID: 7, ParentID: &epic.ID}); err == nil {
+		t.Fatal("expected a parent from another project to be rejected")
+	}
+
+	if _, err := taskSvc.MoveTask(ctx, epic.ID, &step.ID); err == nil {
+		t.Fatal("expected moving a task under its own subtask to be rejected")
+	}
+	if _, err := taskSvc.MoveTask(ctx, epic.ID, &epic.ID); err == nil {
+		t.Fatal("expected moving a task under itself to be rejected")
+	}
+
+	if _, err := taskSvc.UpdateTask(ctx, story.ID, TaskUpdateInput{Title: story.Title, Status: TaskDone, AssigneeID: 7}); !errors.Is(err, ErrTaskHasOpenSubtasks) {
+		t.Fatalf("expected ErrTaskHasOpenSubtasks, got %v", err)
+	}
+	if _, err := taskSvc.UpdateTask(ctx, step.ID, TaskUpdateInput{Title: step.Title, Status: TaskDone, AssigneeID: 7}); err != nil {
+		t.Fatalf("finish subtask: %v", err)
+	}
+
+	for _, entry := range []TimeEntryInput{
+		{TaskID: epic.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 1},
+		{TaskID: story.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 1), Hours: 2},
+		{TaskID: step.ID, UserID: 7, EntryDate: start.AddDate(0, 0, 2), Hours: 3.5},
+	} {
+		if _, err := timeSvc.LogTime(ctx, entry); err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+	}
+	rollup, err := taskSvc.Rollup(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("rollup: %v", err)
+	}
+	if rollup.Subtasks != 2 || rollup.DoneSubtasks != 1 || rollup.PercentDone != 50 || rollup.SubtasksByStatus[TaskTodo] != 1 {
+		t.Fatalf("unexpected subtask counts: %+v", rollup)
+	}
+	if rollup.OwnHours != 1 || rollup.SubtaskHours != 5.5 || rollup.TotalHours != 6.5 {
+		t.Fatalf("unexpected rollup hours: %+v", rollup)
+	}
+
+	moved, err := taskSvc.MoveTask(ctx, step.ID, &epic.ID)
+	if err != nil {
+		t.Fatalf("move task: %v", err)
+	}
+	if moved.ParentID == nil || *moved.ParentID != epic.ID {
+		t.Fatalf("expected step under the epic, got %v", moved.ParentID)
+	}
+	children, err := taskSvc.ListChildren(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("list children: %v", err)
+	}
+	if len(children) != 2 || children[0].ID != story.ID || children[1].ID != step.ID {
+		t.Fatalf("expected story and step as children, got %+v", children)
+	}
+	if _, err := taskSvc.MoveTask(ctx, story.ID, nil); err != nil {
+		t.Fatalf("move to top level: %v", err)
+	}
+	if children, _ = taskSvc.ListChildren(ctx, epic.ID); len(children) != 1 {
+		t.Fatalf("expected only the step left under the epic, got %d", len(children))
+	}
+
+	clone, err := projectSvc.CloneProject(ctx, project.ID, ProjectCopyInput{
+		Project: ProjectInput{Name: "Portal v2", ClientName: "ACME", StartDate: start, OwnerID: 1},
+	})
+	if err != nil {
+		t.Fatalf("clone project: %v", err)
+	}
+	var copied []Task
+	if err := db.Where("project_id = ?", clone.ID).Order("id").Find(&copied).Error; err != nil {
+		t.Fatalf("load copied tasks: %v", err)
+	}
+	if len(copied) != 3 || copied[2].ParentID == nil || *copied[2].ParentID != copied[0].ID || copied[1].ParentID != nil {
+		t.Fatalf("expected the clone to keep the hierarchy, got %+v", copied)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -18,29 +19,50 @@ type TasksPage struct {
 
 // TaskFilter holds list parameters.
 type TaskFilter struct {
-	ProjectID  uint
-	Status     []TaskStatus
-	AssigneeID *uint
-	Page       int
-	PageSize   int
+	ProjectID   uint
+	Status      []TaskStatus
+	AssigneeID  *uint
+	MilestoneID *uint
+	// Tags keeps the tasks that have every one of the named tags, directly
+	// or through their project.
+	Tags     []string
+	Page     int
+	PageSize int
+	// VisibleTo restricts the listing to tasks assigned to the user plus every
+	// task of projects they own or are a member of. Nil means no restriction.
+	VisibleTo *uint
 }
 
 // TaskInput is used for task creation.
 type TaskInput struct {
-	ProjectID   uint
-	Title       string
-	Description string
-	AssigneeID  uint
-	DueDate     *time.Time
+	ProjectID     uint
+	Title         string
+	Description   string
+	AssigneeID    uint
+	DueDate       *time.Time
+	EstimateHours float64
+	// RemainingEstimateHours overrides the remaining work; nil derives it
+	// from the hours logged.
+	RemainingEstimateHours *float64
+	MilestoneID            *uint
+	ParentID               *uint
 }
 
 // TaskUpdateInput is used for task updates.
 type TaskUpdateInput struct {
-	Title       string
-	Description string
-	Status      TaskStatus
-	AssigneeID  uint
-	DueDate     *time.Time
+	Title         string
+	Description   string
+	Status        TaskStatus
+	AssigneeID    uint
+	DueDate       *time.Time
+	EstimateHours float64
+	// RemainingEstimateHours overrides the remaining work; nil derives it
+	// from the hours logged.
+	RemainingEstimateHours *float64
+	MilestoneID            *uint
+	// OverrideDependencies lets the task start or finish while predecessors
+	// are still open.
+	OverrideDependencies bool
 }
 
 // TaskService encapsulates use cases for tasks.
@@ -61,20 +83,33 @@ func (s *TaskService) CreateTask(ctx context.Context, in TaskInput) (*Task, erro
 	if err != nil {
 		return nil, err
 	}
+	if project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
 	if project.Status == ProjectCanceled || project.Status == ProjectCompleted {
 		return nil, errors.New("cannot add tasks to closed project")
 	}
 	if err := validateDueDate(in.DueDate, project); err != nil {
 		return nil, err
 	}
+	if err := validateTaskMilestone(s.db.WithContext(ctx), in.MilestoneID, project.ID, in.DueDate); err != nil {
+		return nil, err
+	}
+	if err := validateTaskParent(s.db.WithContext(ctx), &Task{ProjectID: project.ID, Status: TaskTodo}, in.ParentID); err != nil {
+		return nil, err
+	}
 
 	task := &Task{
-		ProjectID:   in.ProjectID,
-		Title:       in.Title,
-		Description: in.Description,
-		AssigneeID:  in.AssigneeID,
-		DueDate:     in.DueDate,
-		Status:      TaskTodo,
+		ProjectID:              in.ProjectID,
+		Title:                  in.Title,
+		Description:            in.Description,
+		AssigneeID:             in.AssigneeID,
+		DueDate:                in.DueDate,
+		EstimateHours:          in.EstimateHours,
+		RemainingEstimateHours: in.RemainingEstimateHours,
+		MilestoneID:            in.MilestoneID,
+		ParentID:               in.ParentID,
+		Status:                 TaskTodo,
 	}
 	if err := s.db.WithContext(ctx).Create(task).Error; err != nil {
 		return nil, err
@@ -92,6 +127,9 @@ func (s *TaskService) UpdateTask(ctx context.Context, id uint, in TaskUpdateInpu
 		return nil, err
 	}
 
+	if task.Project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
 	if task.Project.Status == ProjectCanceled || task.Project.Status == ProjectCompleted {
 		return nil, errors.New("cannot modify tasks in closed project")
 	}
@@ -99,12 +137,37 @@ func (s *TaskService) UpdateTask(ctx context.Context, id uint, in TaskUpdateInpu
 	if err := validateDueDate(in.DueDate, &task.Project); err != nil {
 		return nil, err
 	}
+	if err := validateTaskMilestone(s.db.WithContext(ctx), in.MilestoneID, task.ProjectID, in.DueDate); err != nil {
+		return nil, err
+	}
+	if in.Status == TaskDone && task.Status != TaskDone {
+		if err := ensureSubtasksDone(s.db.WithContext(ctx), task.ID); err != nil {
+			return nil, err
+		}
+	}
+	if (in.Status == TaskInProgress || in.Status == TaskDone) && in.Status != task.Status && !in.OverrideDependencies {
+		if err := ensurePredecessorsDone(s.db.WithContext(ctx), task.ID); err != nil {
+			return nil, err
+		}
+	}
+	if in.Status != TaskDone && task.ParentID != nil {
+		var parent Task
+		if err := s.db.WithContext(ctx).Select("status").First(&parent, *task.ParentID).Error; err != nil {
+			return nil, err
+		}
+		if parent.Status == TaskDone {
+			return nil, errors.New("parent task is done; reopen it before reopening its subtasks")
+		}
+	}
 
 	task.Title = in.Title
 	task.Description = in.Description
 	task.Status = in.Status
 	task.AssigneeID = in.AssigneeID
 	task.DueDate = in.DueDate
+	task.EstimateHours = in.EstimateHours
+	task.RemainingEstimateHours = in.RemainingEstimateHours
+	task.MilestoneID = in.MilestoneID
 
 	if err := s.db.WithContext(ctx).Save(&task).Error; err != nil {
 		return nil, err
@@ -122,17 +185,7 @@ func (s *TaskService) GetTask(ctx context.Context, id uint) (*Task, error) {
 
 func (s *TaskService) ListTasks(ctx context.Context, filter TaskFilter) (TasksPage, error) {
 	filter = sanitizeTaskFilter(filter)
-	tx := s.db.WithContext(ctx).Model(&Task{}).Preload("Project")
-
-	if filter.ProjectID != 0 {
-		tx = tx.Where("project_id = ?", filter.ProjectID)
-	}
-	if len(filter.Status) > 0 {
-		tx = tx.Where("status IN ?", filter.Status)
-	}
-	if filter.AssigneeID != nil {
-		tx = tx.Where("assignee_id = ?", *filter.AssigneeID)
-	}
+	tx := applyTaskFilter(s.db.WithContext(ctx).Model(&Task{}).Preload("Project"), filter)
 
 	var total int64
 	if err := tx.Count(&total).Error; err != nil {
@@ -151,6 +204,41 @@ func (s *TaskService) ListTasks(ctx context.Context, filter TaskFilter) (TasksPa
 	return TasksPage{Items: tasks, Total: total}, nil
 }
 
+// EachTask streams every task matching the filter (pagination is ignored)
+// to fn in batches.
+func (s *TaskService) EachTask(ctx context.Context, filter TaskFilter, fn func([]Task) error) error {
+	var batch []Task
+	return applyTaskFilter(s.db.WithContext(ctx).Model(&Task{}).Preload("Project"), filter).
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+func applyTaskFilter(tx *gorm.DB, filter TaskFilter) *gorm.DB {
+	if filter.ProjectID != 0 {
+		tx = tx.Where("project_id = ?", filter.ProjectID)
+	}
+	if len(filter.Status) > 0 {
+		tx = tx.Where("status IN ?", filter.Status)
+	}
+	if filter.AssigneeID != nil {
+		tx = tx.Where("assignee_id = ?", *filter.AssigneeID)
+	}
+	if filter.MilestoneID != nil {
+		tx = tx.Where("milestone_id = ?", *filter.MilestoneID)
+	}
+	for _, tag := range filter.Tags {
+		tx = tx.Where("(id IN (?) OR project_id IN (?))", taggedTasks(tx, tag), taggedProjects(tx, tag))
+	}
+	if filter.VisibleTo != nil {
+		newDB := tx.Session(&gorm.Session{NewDB: true})
+		tx = tx.Where("assignee_id = ? OR project_id IN (?) OR project_id IN (?)", *filter.VisibleTo,
+			newDB.Model(&Project{}).Select("id").Where("owner_id = ?", *filter.VisibleTo),
+			newDB.Model(&ProjectMember{}).Select("project_id").Where("user_id = ?", *filter.VisibleTo))
+	}
+	return tx
+}
+
 func (s *TaskService) getProject(ctx context.Context, id uint) (*Project, error) {
 	var project Project
 	if err := s.db.WithContext(ctx).First(&project, id).Error; err != nil {
@@ -169,7 +257,7 @@ func validateTaskInput(in TaskInput) error {
 	if strings.TrimSpace(in.Title) == "" {
 		return errors.New("title is required")
 	}
-	return nil
+	return validateEstimates(in.EstimateHours, in.RemainingEstimateHours)
 }
 
 func validateTaskUpdateInput(in TaskUpdateInput) error {
@@ -184,6 +272,25 @@ func validateTaskUpdateInput(in TaskUpdateInput) error {
 	default:
 		return fmt.Errorf("invalid task status %q", in.Status)
 	}
+	return validateEstimates(in.EstimateHours, in.RemainingEstimateHours)
+}
+
+func validateEstimates(original float64, remaining *float64) error {
+	if err := validateEstimate(original); err != nil {
+		return err
+	}
+	if remaining != nil {
+		if err := validateEstimate(*remaining); err != nil {
+			return fmt.Errorf("remaining %w", err)
+		}
+	}
+	return nil
+}
+
+func validateEstimate(hours float64) error {
+	if math.IsNaN(hours) || math.IsInf(hours, 0) || hours < 0 {
+		return errors.New("estimate must be zero or a positive number of hours")
+	}
 	return nil
 }
 
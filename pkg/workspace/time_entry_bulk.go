@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	bulkReviewBatchSize  = 100
+	maxBulkReviewEntries = 5000
+)
+
+// BulkReviewAction selects what a bulk review does with the entries.
+type BulkReviewAction string
+
+const (
+	BulkApprove BulkReviewAction = "approve"
+	BulkReject  BulkReviewAction = "reject"
+)
+
+// BulkReviewOutcome describes what happened to a single entry.
+type BulkReviewOutcome string
+
+const (
+	BulkOutcomeApproved        BulkReviewOutcome = "approved"
+	BulkOutcomeRejected        BulkReviewOutcome = "rejected"
+	BulkOutcomeAlreadyApproved BulkReviewOutcome = "already_approved"
+	BulkOutcomeAlreadyRejected BulkReviewOutcome = "already_rejected"
+	BulkOutcomeNotFound        BulkReviewOutcome = "not_found"
+	BulkOutcomeForbidden       BulkReviewOutcome = "forbidden"
+	BulkOutcomeFailed          BulkReviewOutcome = "failed"
+)
+
+// BulkReviewInput selects entries either by explicit IDs or by filter.
+// Scope, when set, restricts a non-admin reviewer to the entries they may
+// review; nil means every entry.
+type BulkReviewInput struct {
+	Action     BulkReviewAction
+	IDs        []uint
+	Filter     *TimeEntryFilter
+	ReviewerID uint
+	Reason     string
+	Scope      *ReviewScope
+}
+
+// BulkReviewItem is the per-entry line of the report.
+type BulkReviewItem struct {
+	EntryID uint
+	Outcome BulkReviewOutcome
+	Error   string
+}
+
+// BulkReviewResult summarizes a bulk review.
+type BulkReviewResult struct {
+	Items     []BulkReviewItem
+	Succeeded int
+	Skipped   int
+	Failed    int
+}
+
+// BulkReview approves or rejects many entries in batched transactions. Each
+// entry runs in its own savepoint so one failure does not undo the others.
+func (s *TimeEntryService) BulkReview(ctx context.Context, in BulkReviewInput) (*BulkReviewResult, error) {
+	if in.ReviewerID == 0 {
+		return nil, errors.New("reviewer is required")
+	}
+	switch in.Action {
+	case BulkApprove:
+	case BulkReject:
+		if strings.TrimSpace(in.Reason) == "" {
+			return nil, errors.New("rejection reason is required")
+		}
+	default:
+		return nil, errors.New("invalid bulk action")
+	}
+
+	ids, err := s.resolveBulkIDs(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkReviewResult{Items: make([]BulkReviewItem, 0, len(ids))}
+	for start := 0; start < len(ids); start += bulkReviewBatchSize {
+		end := start + bulkReviewBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		var items []BulkReviewItem
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			items = make([]BulkReviewItem, 0, end-start)
+			for _, id := range ids[start:end] {
+				items = append(items, s.reviewOne(ctx, tx, in, id))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			switch item.Outcome {
+			case BulkOutcomeApproved, BulkOutcomeRejected:
+				result.Succeeded++
+			case BulkOutcomeAlreadyApproved, BulkOutcomeAlreadyRejected:
+				result.Skipped++
+			default:
+				result.Failed++
+			}
+		}
+		result.Items = append(result.Items, items...)
+	}
+	return result, nil
+}
+
+func (s *TimeEntryService) reviewOne(ctx context.Context, tx *gorm.DB, in BulkReviewInput, id uint) BulkReviewItem {
+	item := BulkReviewItem{EntryID: id}
+	err := tx.Transaction(func(sp *gorm.DB) error {
+		var entry TimeEntry
+		if err := sp.First(&entry, id).Error; err != nil {
+			return err
+		}
+		if in.Scope != nil {
+			var task Task
+			if err := sp.First(&task, entry.TaskID).Error; err != nil {
+				return err
+			}
+			if !in.Scope.Allows(task.ProjectID, entry.UserID) {
+				item.Outcome = BulkOutcomeForbidden
+				item.Error = "not allowed to review this entry"
+				return nil
+			}
+		}
+		svc := s.WithTx(sp)
+		switch in.Action {
+		case BulkApprove:
+			if entry.ApprovedAt != nil {
+				item.Outcome = BulkOutcomeAlreadyApproved
+				return nil
+			}
+			if _, err := svc.ApproveEntry(ctx, id, in.ReviewerID); err != nil {
+				return err
+			}
+			item.Outcome = BulkOutcomeApproved
+		case BulkReject:
+			if entry.Status == TimeEntryRejected {
+				item.Outcome = BulkOutcomeAlreadyRejected
+				return nil
+			}
+			if _, err := svc.RejectEntry(ctx, id, in.ReviewerID, in.Reason); err != nil {
+				return err
+			}
+			item.Outcome = BulkOutcomeRejected
+		}
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		item.Outcome = BulkOutcomeNotFound
+		item.Error = "entry not found"
+	} else if err != nil {
+		item.Outcome = BulkOutcomeFailed
+		item.Error = err.Error()
+	}
+	return item
+}
+
+func (s *TimeEntryService) resolveBulkIDs(ctx context.Context, in BulkReviewInput) ([]uint, error) {
+	if len(in.IDs) > 0 && in.Filter != nil {
+		return nil, errors.New("provide either ids or a filter, not both")
+	}
+	if len(in.IDs) > 0 {
+		if len(in.IDs) > maxBulkReviewEntries {
+			return nil, errors.New("too many entries in a single bulk review")
+		}
+		seen := make(map[uint]bool, len(in.IDs))
+		ids := make([]uint, 0, len(in.IDs))
+		for _, id := range in.IDs {
+			if id == 0 || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	if in.Filter == nil {
+		return nil, errors.New("ids or filter are required")
+	}
+
+	filter := *in.Filter
+	if in.Scope != nil {
+		filter = in.Scope.Apply(filter)
+	}
+	if filter.Approved == nil && filter.Status == nil {
+		// por padrão, só a fila pendente é revisada
+		status := TimeEntryPending
+		filter.Status = &status
+	}
+	var ids []uint
+	if err := applyTimeEntryFilter(s.db.WithContext(ctx).Model(&TimeEntry{}), filter).
+		Order("id").
+		Limit(maxBulkReviewEntries+1).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) > maxBulkReviewEntries {
+		return nil, errors.New("too many entries match the filter, narrow it down")
+	}
+	return ids, nil
+}
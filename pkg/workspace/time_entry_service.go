@@ -3,12 +3,28 @@ package workspace
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// ErrInvalidTimeEntryStatus rejects filters on a status other than pending,
+// approved or rejected.
+var ErrInvalidTimeEntryStatus = errors.New("invalid time entry status")
+
+// ParseTimeEntryStatus validates a status name, case-insensitively.
+func ParseTimeEntryStatus(value string) (TimeEntryStatus, error) {
+	switch status := TimeEntryStatus(strings.ToLower(strings.TrimSpace(value))); status {
+	case TimeEntryPending, TimeEntryApproved, TimeEntryRejected:
+		return status, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrInvalidTimeEntryStatus, value)
+	}
+}
+
 // TimeEntriesPage wraps paginated time entries.
 type TimeEntriesPage struct {
 	Items []TimeEntry
@@ -17,11 +33,17 @@ type TimeEntriesPage struct {
 
 // TimeEntryFilter holds filters for list queries.
 type TimeEntryFilter struct {
-	TaskID   *uint
-	UserID   *uint
-	Approved *bool
-	Page     int
-	PageSize int
+	TaskID        *uint
+	UserID        *uint
+	ExcludeUserID *uint
+	ProjectID     *uint
+	ProjectIDs    []uint
+	From          *time.Time
+	Until         *time.Time
+	Approved      *bool
+	Status        *TimeEntryStatus
+	Page          int
+	PageSize      int
 }
 
 // TimeEntryInput holds data to log time.
@@ -42,13 +64,22 @@ type TimeEntryUpdateInput struct {
 
 // TimeEntryService orchestrates time tracking flows.
 type TimeEntryService struct {
-	db *gorm.DB
+	db                    *gorm.DB
+	defaultPolicy         HourPolicy
+	estimateWarningFactor float64
 }
 
 func NewTimeEntryService(db *gorm.DB) *TimeEntryService {
 	return &TimeEntryService{db: db}
 }
 
+// WithTx returns a copy of the service bound to the given transaction.
+func (s *TimeEntryService) WithTx(tx *gorm.DB) *TimeEntryService {
+	clone := *s
+	clone.db = tx
+	return &clone
+}
+
 func (s *TimeEntryService) LogTime(ctx context.Context, in TimeEntryInput) (*TimeEntry, error) {
 	if err := validateTimeEntryInput(in); err != nil {
 		return nil, err
@@ -60,6 +91,26 @@ func (s *TimeEntryService) LogTime(ctx context.Context, in TimeEntryInput) (*Tim
 	if err := validateEntryAgainstTask(in.EntryDate, task); err != nil {
 		return nil, err
 	}
+	if err := ensurePeriodOpen(s.db.WithContext(ctx), task.ProjectID, in.EntryDate); err != nil {
+		return nil, err
+	}
+	if err := ensureTimesheetOpen(s.db.WithContext(ctx), in.UserID, in.EntryDate); err != nil {
+		return nil, err
+	}
+	warnings, err := s.checkHourPolicy(ctx, in.UserID, in.EntryDate, in.Hours, 0)
+	if err != nil {
+		return nil, err
+	}
+	budgetWarnings, err := s.checkBudget(ctx, &task.Project, in.Hours, 0)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, budgetWarnings...)
+	estimateWarnings, err := s.checkEstimate(ctx, task, in.Hours, 0)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, estimateWarnings...)
 
 	entry := &TimeEntry{
 		TaskID:    in.TaskID,
@@ -67,6 +118,8 @@ func (s *TimeEntryService) LogTime(ctx context.Context, in TimeEntryInput) (*Tim
 		EntryDate: in.EntryDate,
 		Hours:     in.Hours,
 		Notes:     in.Notes,
+		Status:    TimeEntryPending,
+		Warnings:  warnings,
 	}
 	if err := s.db.WithContext(ctx).Create(entry).Error; err != nil {
 		return nil, err
@@ -83,27 +136,85 @@ func (s *TimeEntryService) UpdateEntry(ctx context.Context, id uint, in TimeEntr
 	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
 		return nil, err
 	}
+	if entry.InvoiceID != nil {
+		return nil, ErrEntryInvoiced
+	}
 	if entry.ApprovedAt != nil {
 		return nil, errors.New("cannot update approved entry")
 	}
+	for _, date := range []time.Time{entry.EntryDate, in.EntryDate} {
+		if err := ensureTimesheetOpen(s.db.WithContext(ctx), entry.UserID, date); err != nil {
+			return nil, err
+		}
+	}
 
 	task, err := s.loadTask(ctx, entry.TaskID)
 	if err != nil {
 		return nil, err
 	}
+	for _, date := range []time.Time{entry.EntryDate, in.EntryDate} {
+		if err := ensurePeriodOpen(s.db.WithContext(ctx), task.ProjectID, date); err != nil {
+			return nil, err
+		}
+	}
 	if err := validateEntryAgainstTask(in.EntryDate, task); err != nil {
 		return nil, err
 	}
+	warnings, err := s.checkHourPolicy(ctx, entry.UserID, in.EntryDate, in.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	budgetWarnings, err := s.checkBudget(ctx, &task.Project, in.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, budgetWarnings...)
+	estimateWarnings, err := s.checkEstimate(ctx, task, in.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, estimateWarnings...)
 
 	entry.EntryDate = in.EntryDate
 	entry.Hours = in.Hours
 	entry.Notes = in.Notes
+	entry.Warnings = warnings
 	if err := s.db.WithContext(ctx).Save(&entry).Error; err != nil {
 		return nil, err
 	}
 	return &entry, nil
 }
 
+// DeleteEntry removes an entry that could still be edited: not approved nor
+// invoiced, in an open timesheet week and accounting period, of a project
+// that is not archived.
+func (s *TimeEntryService) DeleteEntry(ctx context.Context, id uint) error {
+	var entry TimeEntry
+	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return err
+	}
+	if entry.InvoiceID != nil {
+		return ErrEntryInvoiced
+	}
+	if entry.ApprovedAt != nil {
+		return errors.New("cannot delete approved entry")
+	}
+	if err := ensureTimesheetOpen(s.db.WithContext(ctx), entry.UserID, entry.EntryDate); err != nil {
+		return err
+	}
+	task, err := s.loadTask(ctx, entry.TaskID)
+	if err != nil {
+		return err
+	}
+	if task.Project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	if err := ensurePeriodOpen(s.db.WithContext(ctx), task.ProjectID, entry.EntryDate); err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Delete(&entry).Error
+}
+
 func (s *TimeEntryService) ApproveEntry(ctx context.Context, id uint, approverID uint) (*TimeEntry, error) {
 	if approverID == 0 {
 		return nil, errors.New("approver is required")
@@ -115,7 +226,21 @@ func (s *TimeEntryService) ApproveEntry(ctx context.Context, id uint, approverID
 	if entry.ApprovedAt != nil {
 		return &entry, nil
 	}
+	if entry.Status == TimeEntryRejected {
+		return nil, errors.New("rejected entry must be resubmitted before approval")
+	}
+	task, err := s.loadTask(ctx, entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
+	if err := ensurePeriodOpen(s.db.WithContext(ctx), task.ProjectID, entry.EntryDate); err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
+	entry.Status = TimeEntryApproved
 	entry.ApprovedAt = &now
 	entry.ApprovedBy = &approverID
 	if err := s.db.WithContext(ctx).Save(&entry).Error; err != nil {
@@ -124,31 +249,146 @@ func (s *TimeEntryService) ApproveEntry(ctx context.Context, id uint, approverID
 	return &entry, nil
 }
 
-func (s *TimeEntryService) GetEntry(ctx context.Context, id uint) (*TimeEntry, error) {
+// RejectEntry sends a pending entry back to its author with a reason. A
+// submitted or approved week holding the entry goes back to draft so the
+// author can correct it.
+func (s *TimeEntryService) RejectEntry(ctx context.Context, id uint, reviewerID uint, reason string) (*TimeEntry, error) {
+	if reviewerID == 0 {
+		return nil, errors.New("reviewer is required")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("rejection reason is required")
+	}
 	var entry TimeEntry
 	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
 		return nil, err
 	}
+	if entry.ApprovedAt != nil {
+		return nil, errors.New("cannot reject approved entry, reopen it first")
+	}
+	if entry.Status == TimeEntryRejected {
+		return &entry, nil
+	}
+	if _, err := s.ensureEntryReviewable(ctx, &entry); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	entry.Status = TimeEntryRejected
+	entry.RejectedAt = &now
+	entry.RejectedBy = &reviewerID
+	entry.RejectionReason = reason
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// a semana volta a rascunho para o autor corrigir e reenviar
+		if err := reopenTimesheetWeek(tx, entry.UserID, entry.EntryDate, reviewerID, reason); err != nil {
+			return err
+		}
+		return tx.Save(&entry).Error
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &entry, nil
 }
 
-func (s *TimeEntryService) ListEntries(ctx context.Context, filter TimeEntryFilter) (TimeEntriesPage, error) {
-	filter = sanitizeTimeEntryFilter(filter)
+// ResubmitEntry puts a rejected entry back in the approval queue. Its hours
+// count again, so the hour policy and the project budget are checked as in
+// UpdateEntry.
+func (s *TimeEntryService) ResubmitEntry(ctx context.Context, id uint) (*TimeEntry, error) {
+	var entry TimeEntry
+	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	if entry.Status != TimeEntryRejected {
+		return nil, errors.New("only rejected entries can be resubmitted")
+	}
+	if err := ensureTimesheetOpen(s.db.WithContext(ctx), entry.UserID, entry.EntryDate); err != nil {
+		return nil, err
+	}
+	task, err := s.ensureEntryReviewable(ctx, &entry)
+	if err != nil {
+		return nil, err
+	}
+	// horas rejeitadas ficam fora dos limites; voltam a contar ao reenviar
+	warnings, err := s.checkHourPolicy(ctx, entry.UserID, entry.EntryDate, entry.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	budgetWarnings, err := s.checkBudget(ctx, &task.Project, entry.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, budgetWarnings...)
+	estimateWarnings, err := s.checkEstimate(ctx, task, entry.Hours, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, estimateWarnings...)
 
-	tx := s.db.WithContext(ctx).Model(&TimeEntry{})
-	if filter.TaskID != nil {
-		tx = tx.Where("task_id = ?", *filter.TaskID)
+	entry.Warnings = warnings
+	entry.Status = TimeEntryPending
+	entry.RejectedAt = nil
+	entry.RejectedBy = nil
+	if err := s.db.WithContext(ctx).Save(&entry).Error; err != nil {
+		return nil, err
 	}
-	if filter.UserID != nil {
-		tx = tx.Where("user_id = ?", *filter.UserID)
+	return &entry, nil
+}
+
+// ReopenEntry reverts an approval so the entry can be corrected; its week
+// goes back to draft like in RejectEntry.
+func (s *TimeEntryService) ReopenEntry(ctx context.Context, id uint, adminID uint, justification string) (*TimeEntry, error) {
+	if adminID == 0 {
+		return nil, errors.New("admin is required")
 	}
-	if filter.Approved != nil {
-		if *filter.Approved {
-			tx = tx.Where("approved_at IS NOT NULL")
-		} else {
-			tx = tx.Where("approved_at IS NULL")
+	justification = strings.TrimSpace(justification)
+	if justification == "" {
+		return nil, errors.New("reopen justification is required")
+	}
+	var entry TimeEntry
+	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	if entry.ApprovedAt == nil {
+		return nil, errors.New("only approved entries can be reopened")
+	}
+	if entry.InvoiceID != nil {
+		return nil, ErrEntryInvoiced
+	}
+	if _, err := s.ensureEntryReviewable(ctx, &entry); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	entry.Status = TimeEntryPending
+	entry.ApprovedAt = nil
+	entry.ApprovedBy = nil
+	entry.ReopenedAt = &now
+	entry.ReopenedBy = &adminID
+	entry.ReopenReason = justification
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reopenTimesheetWeek(tx, entry.UserID, entry.EntryDate, adminID, justification); err != nil {
+			return err
 		}
+		return tx.Save(&entry).Error
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &entry, nil
+}
+
+func (s *TimeEntryService) GetEntry(ctx context.Context, id uint) (*TimeEntry, error) {
+	var entry TimeEntry
+	if err := s.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (s *TimeEntryService) ListEntries(ctx context.Context, filter TimeEntryFilter) (TimeEntriesPage, error) {
+	filter = sanitizeTimeEntryFilter(filter)
+
+	tx := applyTimeEntryFilter(s.db.WithContext(ctx).Model(&TimeEntry{}), filter)
 
 	var total int64
 	if err := tx.Count(&total).Error; err != nil {
@@ -167,6 +407,16 @@ func (s *TimeEntryService) ListEntries(ctx context.Context, filter TimeEntryFilt
 	return TimeEntriesPage{Items: entries, Total: total}, nil
 }
 
+// EachEntry streams every entry matching the filter (pagination is ignored)
+// to fn in batches.
+func (s *TimeEntryService) EachEntry(ctx context.Context, filter TimeEntryFilter, fn func([]TimeEntry) error) error {
+	var batch []TimeEntry
+	return applyTimeEntryFilter(s.db.WithContext(ctx).Model(&TimeEntry{}), filter).
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
 func (s *TimeEntryService) loadTask(ctx context.Context, id uint) (*Task, error) {
 	var task Task
 	if err := s.db.WithContext(ctx).
@@ -177,6 +427,60 @@ func (s *TimeEntryService) loadTask(ctx context.Context, id uint) (*Task, error)
 	return &task, nil
 }
 
+// ensureEntryReviewable rejects review changes to entries of archived
+// projects or dated inside a closed accounting period, and returns the task
+// of the entry.
+func (s *TimeEntryService) ensureEntryReviewable(ctx context.Context, entry *TimeEntry) (*Task, error) {
+	task, err := s.loadTask(ctx, entry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Project.ArchivedAt != nil {
+		return nil, ErrProjectArchived
+	}
+	if err := ensurePeriodOpen(s.db.WithContext(ctx), task.ProjectID, entry.EntryDate); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func applyTimeEntryFilter(tx *gorm.DB, filter TimeEntryFilter) *gorm.DB {
+	if filter.TaskID != nil {
+		tx = tx.Where("task_id = ?", *filter.TaskID)
+	}
+	if filter.UserID != nil {
+		tx = tx.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.ProjectID != nil {
+		tx = tx.Where("task_id IN (?)", tx.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id = ?", *filter.ProjectID))
+	}
+	if filter.ProjectIDs != nil {
+		tx = tx.Where("task_id IN (?)", tx.Session(&gorm.Session{NewDB: true}).
+			Model(&Task{}).Select("id").Where("project_id IN ?", filter.ProjectIDs))
+	}
+	if filter.ExcludeUserID != nil {
+		tx = tx.Where("user_id <> ?", *filter.ExcludeUserID)
+	}
+	if filter.From != nil {
+		tx = tx.Where("entry_date >= ?", *filter.From)
+	}
+	if filter.Until != nil {
+		tx = tx.Where("entry_date <= ?", *filter.Until)
+	}
+	if filter.Approved != nil {
+		if *filter.Approved {
+			tx = tx.Where("approved_at IS NOT NULL")
+		} else {
+			tx = tx.Where("approved_at IS NULL")
+		}
+	}
+	if filter.Status != nil {
+		tx = tx.Where("status = ?", *filter.Status)
+	}
+	return tx
+}
+
 func validateTimeEntryInput(in TimeEntryInput) error {
 	if in.TaskID == 0 {
 		return errors.New("task is required")
@@ -230,6 +534,9 @@ func sanitizeTimeEntryFilter(filter TimeEntryFilter) TimeEntryFilter {
 }
 
 func validateEntryAgainstTask(entryDate time.Time, task *Task) error {
+	if task.Project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
 	if entryDate.Before(task.Project.StartDate) {
 		return errors.New("entry date cannot be before project start")
 	}
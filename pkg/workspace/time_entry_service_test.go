@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -164,3 +165,309 @@ func TestTimeEntryService_LogTimeDateValidation(t *testing.T) {
 		t.Fatal("expected error when entry date after project end")
 	}
 }
+
+func TestTimeEntryService_RejectResubmitReopen(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   time.Now().UTC().Add(-time.Hour),
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 1,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	entry, err := timeSvc.LogTime(ctx, TimeEntryInput{
+		TaskID:    task.ID,
+		UserID:    1,
+		EntryDate: time.Now().UTC(),
+		Hours:     2,
+	})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+
+	if _, err := timeSvc.RejectEntry(ctx, entry.ID, 2, "  "); err == nil {
+		t.Fatal("expected error when rejecting without reason")
+	}
+	rejected, err := timeSvc.RejectEntry(ctx, entry.ID, 2, "tarefa errada")
+	if err != nil {
+		t.Fatalf("reject entry: %v", err)
+	}
+	if rejected.Status != TimeEntryRejected || rejected.RejectionReason != "tarefa errada" {
+		t.Fatalf("unexpected rejected entry: %+v", rejected)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, entry.ID, 2); err == nil {
+		t.Fatal("expected error when approving rejected entry")
+	}
+
+	if _, err := timeSvc.UpdateEntry(ctx, entry.ID, TimeEntryUpdateInput{
+		EntryDate: time.Now().UTC(),
+		Hours:     3,
+	}); err != nil {
+		t.Fatalf("update rejected entry: %v", err)
+	}
+	resubmitted, err := timeSvc.ResubmitEntry(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("resubmit entry: %v", err)
+	}
+	if resubmitted.Status != TimeEntryPending || resubmitted.RejectedAt != nil {
+		t.Fatalf("expected pending entry after resubmit, got %+v", resubmitted)
+	}
+
+	if _, err := timeSvc.ReopenEntry(ctx, entry.ID, 9, "engano"); err == nil {
+		t.Fatal("expected error when reopening pending entry")
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, entry.ID, 2); err != nil {
+		t.Fatalf("approve entry: %v", err)
+	}
+	if _, err := timeSvc.ReopenEntry(ctx, entry.ID, 9, ""); err == nil {
+		t.Fatal("expected error when reopening without justification")
+	}
+	reopened, err := timeSvc.ReopenEntry(ctx, entry.ID, 9, "aprovado por engano")
+	if err != nil {
+		t.Fatalf("reopen entry: %v", err)
+	}
+	if reopened.ApprovedAt != nil || reopened.Status != TimeEntryPending {
+		t.Fatalf("expected reopened entry to be pending, got %+v", reopened)
+	}
+	if reopened.ReopenedBy == nil || *reopened.ReopenedBy != 9 || reopened.ReopenReason != "aprovado por engano" {
+		t.Fatalf("expected reopen audit fields, got %+v", reopened)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, entry.ID, TimeEntryUpdateInput{
+		EntryDate: time.Now().UTC(),
+		Hours:     1,
+	}); err != nil {
+		t.Fatalf("update reopened entry: %v", err)
+	}
+}
+
+func TestEvaluateHourPolicy(t *testing.T) {
+	policy := HourPolicy{
+		MaxDailyHours:       10,
+		MaxWeeklyHours:      50,
+		DailyOvertimeHours:  8,
+		WeeklyOvertimeHours: 40,
+		WeekendRule:         WeekendWarn,
+	}
+	monday := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	saturday := time.Date(2025, 2, 15, 9, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		policy    HourPolicy
+		date      time.Time
+		hours     float64
+		dayTotal  float64
+		weekTotal float64
+		warnings  int
+		wantErr   bool
+	}{
+		{name: "within limits", policy: policy, date: monday, hours: 4, warnings: 0},
+		{name: "daily overtime", policy: policy, date: monday, hours: 3, dayTotal: 6, warnings: 1},
+		{name: "weekly overtime", policy: policy, date: monday, hours: 2, weekTotal: 39, warnings: 1},
+		{name: "daily limit", policy: policy, date: monday, hours: 3, dayTotal: 8, wantErr: true},
+		{name: "weekly limit", policy: policy, date: monday, hours: 2, weekTotal: 49, wantErr: true},
+		{name: "weekend warning", policy: policy, date: saturday, hours: 2, warnings: 1},
+		{name: "weekend blocked", policy: HourPolicy{WeekendRule: WeekendBlock}, date: saturday, hours: 2, wantErr: true},
+		{name: "disabled policy", policy: HourPolicy{}, date: saturday, hours: 24, weekTotal: 100, warnings: 0},
+	}
+	for _, tc := range cases {
+		warnings, err := evaluateHourPolicy(tc.policy, tc.date, tc.hours, tc.dayTotal, tc.weekTotal)
+		if tc.wantErr {
+			if !errors.Is(err, ErrPolicyViolation) {
+				t.Fatalf("%s: expected policy violation, got %v", tc.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(warnings) != tc.warnings {
+			t.Fatalf("%s: expected %d warnings, got %v", tc.name, tc.warnings, warnings)
+		}
+	}
+}
+
+func TestValidateHourPolicy(t *testing.T) {
+	if err := ValidateHourPolicy(HourPolicy{WeekendRule: WeekendAllow}); err != nil {
+		t.Fatalf("expected the all-off policy to be valid, got %v", err)
+	}
+	for name, policy := range map[string]HourPolicy{
+		"misspelled weekend rule": {WeekendRule: "Block"},
+		"empty weekend rule":      {},
+		"negative overtime":       {DailyOvertimeHours: -8, WeekendRule: WeekendAllow},
+		"daily limit above a day": {MaxDailyHours: 25, WeekendRule: WeekendAllow},
+	} {
+		if err := ValidateHourPolicy(policy); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestTimeEntryService_HourPolicyOverride(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db).WithDefaultPolicy(HourPolicy{
+		MaxDailyHours:      24,
+		DailyOvertimeHours: 8,
+		WeekendRule:        WeekendAllow,
+	})
+	ctx := context.Background()
+
+	day := time.Date(2025, 2, 11, 9, 0, 0, 0, time.UTC)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   day.AddDate(0, 0, -7),
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 5,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	first, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 5, EntryDate: day, Hours: 6})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if len(first.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", first.Warnings)
+	}
+	second, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 5, EntryDate: day, Hours: 3})
+	if err != nil {
+		t.Fatalf("log overtime: %v", err)
+	}
+	if len(second.Warnings) != 1 {
+		t.Fatalf("expected overtime warning, got %v", second.Warnings)
+	}
+
+	if _, err := timeSvc.SetUserPolicy(ctx, 5, HourPolicy{MaxDailyHours: 10, WeekendRule: WeekendAllow}); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+	if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 5, EntryDate: day, Hours: 2}); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected daily limit violation, got %v", err)
+	}
+	// editar um lançamento existente não conta as horas dele duas vezes
+	if _, err := timeSvc.UpdateEntry(ctx, first.ID, TimeEntryUpdateInput{EntryDate: day, Hours: 7}); err != nil {
+		t.Fatalf("update within limit: %v", err)
+	}
+
+	if err := timeSvc.ResetUserPolicy(ctx, 5); err != nil {
+		t.Fatalf("reset policy: %v", err)
+	}
+	policy, err := timeSvc.EffectivePolicy(ctx, 5)
+	if err != nil {
+		t.Fatalf("effective policy: %v", err)
+	}
+	if policy.MaxDailyHours != 24 {
+		t.Fatalf("expected default policy after reset, got %+v", policy)
+	}
+}
+
+func TestTimeEntryService_BulkReview(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	ctx := context.Background()
+
+	start := time.Now().UTC().Add(-72 * time.Hour)
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   start,
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 4,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	var ids []uint
+	for i := 0; i < 4; i++ {
+		entry, err := timeSvc.LogTime(ctx, TimeEntryInput{
+			TaskID:    task.ID,
+			UserID:    4,
+			EntryDate: start.Add(time.Duration(i+1) * time.Hour),
+			Hours:     1,
+		})
+		if err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+	if _, err := timeSvc.ApproveEntry(ctx, ids[0], 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, ids[1], 1, "horas erradas"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+
+	result, err := timeSvc.BulkReview(ctx, BulkReviewInput{
+		Action:     BulkApprove,
+		IDs:        []uint{ids[0], ids[1], ids[2], 9999},
+		ReviewerID: 1,
+	})
+	if err != nil {
+		t.Fatalf("bulk approve: %v", err)
+	}
+	want := []BulkReviewOutcome{BulkOutcomeAlreadyApproved, BulkOutcomeFailed, BulkOutcomeApproved, BulkOutcomeNotFound}
+	for i, item := range result.Items {
+		if item.Outcome != want[i] {
+			t.Fatalf("item %d: expected %s, got %s (%s)", i, want[i], item.Outcome, item.Error)
+		}
+	}
+	if result.Succeeded != 1 || result.Skipped != 1 || result.Failed != 2 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+
+	// sem status no filtro, só os pendentes do projeto são revisados
+	result, err = timeSvc.BulkReview(ctx, BulkReviewInput{
+		Action:     BulkReject,
+		Filter:     &TimeEntryFilter{ProjectID: &project.ID},
+		ReviewerID: 1,
+		Reason:     "fora do escopo",
+	})
+	if err != nil {
+		t.Fatalf("bulk reject by filter: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].EntryID != ids[3] || result.Items[0].Outcome != BulkOutcomeRejected {
+		t.Fatalf("unexpected filter result: %+v", result.Items)
+	}
+
+	if _, err := timeSvc.BulkReview(ctx, BulkReviewInput{Action: BulkReject, IDs: ids, ReviewerID: 1}); err == nil {
+		t.Fatal("expected error when rejecting without reason")
+	}
+}
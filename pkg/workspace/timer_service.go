@@ -0,0 +1,280 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNoActiveTimer is returned when the user has no running or paused timer.
+	ErrNoActiveTimer = errors.New("no active timer")
+	// ErrTimerAlreadyActive prevents a second running or paused timer; the
+	// unique index on active timers backs it against concurrent starts.
+	ErrTimerAlreadyActive = errors.New("user already has an active timer")
+)
+
+// MaxTimerDuration caps the time a single timer can log, as an entry cannot
+// exceed 24 hours.
+const MaxTimerDuration = 24 * time.Hour
+
+// TimerInput starts a timer on a task.
+type TimerInput struct {
+	UserID uint
+	TaskID uint
+	Notes  string
+}
+
+// TimerStopResult holds the stopped timer and the entry it produced.
+type TimerStopResult struct {
+	Timer Timer
+	Entry *TimeEntry
+}
+
+// TimerService orchestrates start/stop timers that produce time entries.
+type TimerService struct {
+	db         *gorm.DB
+	timeSvc    *TimeEntryService
+	maxRunning time.Duration
+	now        func() time.Time
+}
+
+// NewTimerService builds the service; timers running longer than maxRunning
+// are capped and stopped automatically (zero disables the automatic stop).
+// Timers never log more than MaxTimerDuration.
+func NewTimerService(db *gorm.DB, timeSvc *TimeEntryService, maxRunning time.Duration) *TimerService {
+	return &TimerService{
+		db:         db,
+		timeSvc:    timeSvc,
+		maxRunning: maxRunning,
+		now:        func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func (s *TimerService) Start(ctx context.Context, in TimerInput) (*Timer, error) {
+	if in.UserID == 0 {
+		return nil, errors.New("user is required")
+	}
+	if in.TaskID == 0 {
+		return nil, errors.New("task is required")
+	}
+
+	var timer *Timer
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		active, err := findActiveTimer(tx, in.UserID)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return ErrTimerAlreadyActive
+		}
+
+		now := s.now()
+		task, err := s.timeSvc.WithTx(tx).loadTask(ctx, in.TaskID)
+		if err != nil {
+			return err
+		}
+		if err := validateEntryAgainstTask(now, task); err != nil {
+			return err
+		}
+
+		timer = &Timer{
+			UserID:        in.UserID,
+			TaskID:        in.TaskID,
+			Status:        TimerRunning,
+			Notes:         in.Notes,
+			StartedAt:     now,
+			LastResumedAt: &now,
+		}
+		if err := tx.Create(timer).Error; errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrTimerAlreadyActive
+		} else if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (s *TimerService) Pause(ctx context.Context, userID uint) (*Timer, error) {
+	timer, err := s.Current(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status != TimerRunning {
+		return nil, errors.New("timer is not running")
+	}
+	timer.ElapsedSeconds = s.elapsedSeconds(timer, s.now())
+	timer.Status = TimerPaused
+	timer.LastResumedAt = nil
+	if err := s.db.WithContext(ctx).Save(timer).Error; err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (s *TimerService) Resume(ctx context.Context, userID uint) (*Timer, error) {
+	timer, err := s.Current(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if timer.Status != TimerPaused {
+		return nil, errors.New("timer is not paused")
+	}
+	now := s.now()
+	timer.Status = TimerRunning
+	timer.LastResumedAt = &now
+	if err := s.db.WithContext(ctx).Save(timer).Error; err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+// Stop ends the active timer and logs its duration as a time entry.
+func (s *TimerService) Stop(ctx context.Context, userID uint) (*TimerStopResult, error) {
+	timer, err := s.Current(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var result *TimerStopResult
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entry, err := s.stopTimer(ctx, tx, timer, false)
+		if err != nil {
+			return err
+		}
+		result = &TimerStopResult{Timer: *timer, Entry: entry}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Discard drops the active timer without logging time.
+func (s *TimerService) Discard(ctx context.Context, userID uint) error {
+	timer, err := s.Current(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Delete(timer).Error
+}
+
+// Current returns the running or paused timer of the user.
+func (s *TimerService) Current(ctx context.Context, userID uint) (*Timer, error) {
+	timer, err := findActiveTimer(s.db.WithContext(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	if timer == nil {
+		return nil, ErrNoActiveTimer
+	}
+	return timer, nil
+}
+
+// AutoStopExpired stops timers that have been running beyond the configured
+// limit. Timers whose entry cannot be logged are paused so the user can act.
+func (s *TimerService) AutoStopExpired(ctx context.Context) (int, error) {
+	if s.maxRunning <= 0 {
+		return 0, nil
+	}
+	var running []Timer
+	if err := s.db.WithContext(ctx).
+		Where("status = ?", TimerRunning).
+		Find(&running).Error; err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	stopped := 0
+	for i := range running {
+		timer := &running[i]
+		if time.Duration(s.elapsedSeconds(timer, now))*time.Second < s.maxRunning {
+			continue
+		}
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			_, err := s.stopTimer(ctx, tx, timer, true)
+			return err
+		})
+		if err != nil {
+			timer.ElapsedSeconds = int64(s.timerLimit() / time.Second)
+			timer.Status = TimerPaused
+			timer.LastResumedAt = nil
+			if err := s.db.WithContext(ctx).Save(timer).Error; err != nil {
+				return stopped, err
+			}
+			continue
+		}
+		stopped++
+	}
+	return stopped, nil
+}
+
+func (s *TimerService) stopTimer(ctx context.Context, tx *gorm.DB, timer *Timer, auto bool) (*TimeEntry, error) {
+	now := s.now()
+	seconds := s.elapsedSeconds(timer, now)
+	if limit := s.timerLimit(); time.Duration(seconds)*time.Second > limit {
+		seconds = int64(limit / time.Second)
+	}
+	hours := math.Round(float64(seconds)/36) / 100
+	if hours <= 0 {
+		return nil, errors.New("timer too short to be logged")
+	}
+
+	entry, err := s.timeSvc.WithTx(tx).LogTime(ctx, TimeEntryInput{
+		TaskID:    timer.TaskID,
+		UserID:    timer.UserID,
+		EntryDate: timer.StartedAt,
+		Hours:     hours,
+		Notes:     timer.Notes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timer.ElapsedSeconds = seconds
+	timer.Status = TimerStopped
+	timer.LastResumedAt = nil
+	timer.StoppedAt = &now
+	timer.AutoStopped = auto
+	timer.TimeEntryID = &entry.ID
+	if err := tx.Save(timer).Error; err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// timerLimit is maxRunning when set, never above MaxTimerDuration.
+func (s *TimerService) timerLimit() time.Duration {
+	if s.maxRunning > 0 && s.maxRunning < MaxTimerDuration {
+		return s.maxRunning
+	}
+	return MaxTimerDuration
+}
+
+func (s *TimerService) elapsedSeconds(timer *Timer, now time.Time) int64 {
+	seconds := timer.ElapsedSeconds
+	if timer.Status == TimerRunning && timer.LastResumedAt != nil {
+		seconds += int64(now.Sub(*timer.LastResumedAt) / time.Second)
+	}
+	return seconds
+}
+
+func findActiveTimer(tx *gorm.DB, userID uint) (*Timer, error) {
+	var timer Timer
+	err := tx.Where("user_id = ? AND status IN ?", userID, []TimerStatus{TimerRunning, TimerPaused}).
+		First(&timer).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &timer, nil
+}
@@ -0,0 +1,178 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestTimerService_StartPauseStop(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	timerSvc := NewTimerService(db, timeSvc, 8*time.Hour)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   time.Now().UTC().Add(-72 * time.Hour),
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 4,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	clock := time.Now().UTC().Add(-10 * time.Hour)
+	timerSvc.now = func() time.Time { return clock }
+
+	if _, err := timerSvc.Current(ctx, 4); !errors.Is(err, ErrNoActiveTimer) {
+		t.Fatalf("expected no active timer, got %v", err)
+	}
+	if _, err := timerSvc.Start(ctx, TimerInput{UserID: 4, TaskID: task.ID, Notes: "pareamento"}); err != nil {
+		t.Fatalf("start timer: %v", err)
+	}
+	if _, err := timerSvc.Start(ctx, TimerInput{UserID: 4, TaskID: task.ID}); !errors.Is(err, ErrTimerAlreadyActive) {
+		t.Fatalf("expected ErrTimerAlreadyActive when starting a second timer, got %v", err)
+	}
+	// um início concorrente que passe da verificação esbarra no índice único
+	if err := db.Create(&Timer{UserID: 4, TaskID: task.ID, Status: TimerPaused, StartedAt: clock}).Error; !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("expected the unique index to reject a second active timer, got %v", err)
+	}
+
+	clock = clock.Add(90 * time.Minute)
+	paused, err := timerSvc.Pause(ctx, 4)
+	if err != nil {
+		t.Fatalf("pause timer: %v", err)
+	}
+	if paused.ElapsedSeconds != 90*60 {
+		t.Fatalf("expected 90 minutes elapsed, got %d", paused.ElapsedSeconds)
+	}
+
+	clock = clock.Add(2 * time.Hour)
+	if _, err := timerSvc.Resume(ctx, 4); err != nil {
+		t.Fatalf("resume timer: %v", err)
+	}
+	clock = clock.Add(30 * time.Minute)
+
+	result, err := timerSvc.Stop(ctx, 4)
+	if err != nil {
+		t.Fatalf("stop timer: %v", err)
+	}
+	if result.Entry == nil || result.Entry.Hours != 2 || result.Entry.Notes != "pareamento" {
+		t.Fatalf("unexpected entry from timer: %+v", result.Entry)
+	}
+	if result.Timer.Status != TimerStopped || result.Timer.TimeEntryID == nil {
+		t.Fatalf("unexpected stopped timer: %+v", result.Timer)
+	}
+	if _, err := timerSvc.Current(ctx, 4); !errors.Is(err, ErrNoActiveTimer) {
+		t.Fatalf("expected no active timer after stop, got %v", err)
+	}
+}
+
+func TestTimerService_AutoStopExpired(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	timerSvc := NewTimerService(db, timeSvc, 4*time.Hour)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   time.Now().UTC().Add(-72 * time.Hour),
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 4,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	clock := time.Now().UTC().Add(-30 * time.Hour)
+	timerSvc.now = func() time.Time { return clock }
+	if _, err := timerSvc.Start(ctx, TimerInput{UserID: 4, TaskID: task.ID}); err != nil {
+		t.Fatalf("start timer: %v", err)
+	}
+
+	clock = clock.Add(3 * time.Hour)
+	if n, err := timerSvc.AutoStopExpired(ctx); err != nil || n != 0 {
+		t.Fatalf("expected no timers stopped yet, got %d %v", n, err)
+	}
+
+	clock = clock.Add(20 * time.Hour)
+	n, err := timerSvc.AutoStopExpired(ctx)
+	if err != nil {
+		t.Fatalf("auto stop: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected one timer stopped, got %d", n)
+	}
+
+	page, err := timeSvc.ListEntries(ctx, TimeEntryFilter{UserID: ptrUint(4)})
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	if page.Total != 1 || page.Items[0].Hours != 4 {
+		t.Fatalf("expected a capped 4h entry, got %+v", page.Items)
+	}
+}
+
+func TestTimerService_StopCapsAtADayWithoutLimit(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	timerSvc := NewTimerService(db, timeSvc, 0)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Projeto",
+		ClientName: "Cliente",
+		StartDate:  time.Now().UTC().Add(-72 * time.Hour),
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Task", AssigneeID: 4})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	clock := time.Now().UTC().Add(-40 * time.Hour)
+	timerSvc.now = func() time.Time { return clock }
+	if _, err := timerSvc.Start(ctx, TimerInput{UserID: 4, TaskID: task.ID}); err != nil {
+		t.Fatalf("start timer: %v", err)
+	}
+
+	clock = clock.Add(30 * time.Hour)
+	result, err := timerSvc.Stop(ctx, 4)
+	if err != nil {
+		t.Fatalf("a timer left running past a day must still stop: %v", err)
+	}
+	if result.Entry.Hours != 24 {
+		t.Fatalf("expected the entry capped at 24h, got %v", result.Entry.Hours)
+	}
+}
@@ -0,0 +1,497 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrTimesheetLocked is returned when entries of a submitted week are touched.
+var ErrTimesheetLocked = errors.New("timesheet for this week is already submitted")
+
+// TimesheetsPage wraps paginated timesheets.
+type TimesheetsPage struct {
+	Items []Timesheet
+	Total int64
+}
+
+// TimesheetFilter holds filters for list queries.
+type TimesheetFilter struct {
+	UserID   *uint
+	Status   []TimesheetStatus
+	Page     int
+	PageSize int
+}
+
+// TimesheetWeek is the grid view of a user's week.
+type TimesheetWeek struct {
+	Timesheet Timesheet
+	Days      []time.Time
+	Rows      []TimesheetRow
+	Entries   []TimeEntry
+	Total     float64
+}
+
+// TimesheetRow sums the hours of one task for each day of the week.
+type TimesheetRow struct {
+	TaskID uint
+	Hours  []float64
+	Total  float64
+}
+
+// TimesheetCellInput sets the hours of a task on a given day.
+type TimesheetCellInput struct {
+	TaskID uint
+	Date   time.Time
+	Hours  float64
+	Notes  string
+}
+
+// TimesheetService orchestrates weekly timesheet flows.
+type TimesheetService struct {
+	db      *gorm.DB
+	timeSvc *TimeEntryService
+}
+
+func NewTimesheetService(db *gorm.DB, timeSvc *TimeEntryService) *TimesheetService {
+	return &TimesheetService{db: db, timeSvc: timeSvc}
+}
+
+func (s *TimesheetService) GetWeek(ctx context.Context, userID uint, year, week int) (*TimesheetWeek, error) {
+	if userID == 0 {
+		return nil, errors.New("user is required")
+	}
+	start, err := isoWeekStart(year, week)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := findTimesheet(s.db.WithContext(ctx), userID, year, week)
+	if err != nil {
+		return nil, err
+	}
+	if sheet == nil {
+		sheet = &Timesheet{UserID: userID, Year: year, Week: week, Status: TimesheetDraft}
+	}
+
+	var entries []TimeEntry
+	if err := s.db.WithContext(ctx).
+		Where("user_id = ? AND entry_date >= ? AND entry_date < ?", userID, start, start.AddDate(0, 0, 7)).
+		Order("entry_date ASC, id ASC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return buildTimesheetWeek(*sheet, start, entries), nil
+}
+
+// SaveWeek applies a batch of grid cells in a single transaction. Each cell
+// replaces the editable hours of the task on that day with a single entry;
+// zero removes them. Saving a rejected cell sends it back for approval.
+func (s *TimesheetService) SaveWeek(ctx context.Context, userID uint, year, week int, cells []TimesheetCellInput) (*TimesheetWeek, error) {
+	if userID == 0 {
+		return nil, errors.New("user is required")
+	}
+	start, err := isoWeekStart(year, week)
+	if err != nil {
+		return nil, err
+	}
+	if len(cells) == 0 {
+		return nil, errors.New("at least one cell is required")
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sheet, err := findTimesheet(tx, userID, year, week)
+		if err != nil {
+			return err
+		}
+		if sheet != nil && sheet.Status != TimesheetDraft {
+			return ErrTimesheetLocked
+		}
+
+		timeSvc := s.timeSvc.WithTx(tx)
+		for _, cell := range cells {
+			if err := saveTimesheetCell(ctx, tx, timeSvc, userID, start, cell); err != nil {
+				return fmt.Errorf("task %d on %s: %w", cell.TaskID, cell.Date.UTC().Format("2006-01-02"), err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetWeek(ctx, userID, year, week)
+}
+
+func (s *TimesheetService) Submit(ctx context.Context, userID uint, year, week int) (*Timesheet, error) {
+	if userID == 0 {
+		return nil, errors.New("user is required")
+	}
+	if _, err := isoWeekStart(year, week); err != nil {
+		return nil, err
+	}
+
+	var out *Timesheet
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		sheet, err := findTimesheet(tx, userID, year, week)
+		if err != nil {
+			return err
+		}
+		if sheet == nil {
+			sheet = &Timesheet{UserID: userID, Year: year, Week: week, Status: TimesheetDraft}
+		}
+		if sheet.Status != TimesheetDraft {
+			return fmt.Errorf("cannot submit timesheet in status %s", sheet.Status)
+		}
+		now := time.Now().UTC()
+		sheet.Status = TimesheetSubmitted
+		sheet.SubmittedAt = &now
+		if err := tx.Save(sheet).Error; err != nil {
+			return err
+		}
+		out = sheet
+		return nil
+	})
+	return out, err
+}
+
+// Approve approves the timesheet and every pending entry of its week.
+func (s *TimesheetService) Approve(ctx context.Context, id uint, approverID uint) (*Timesheet, error) {
+	if approverID == 0 {
+		return nil, errors.New("approver is required")
+	}
+
+	var sheet Timesheet
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&sheet, id).Error; err != nil {
+			return err
+		}
+		if sheet.Status != TimesheetSubmitted {
+			return errors.New("only submitted timesheets can be approved")
+		}
+		start, err := isoWeekStart(sheet.Year, sheet.Week)
+		if err != nil {
+			return err
+		}
+
+		weekEntries := tx.Model(&TimeEntry{}).
+			Where("user_id = ? AND entry_date >= ? AND entry_date < ?", sheet.UserID, start, start.AddDate(0, 0, 7))
+
+		var rejected int64
+		if err := weekEntries.Session(&gorm.Session{}).
+			Where("status = ?", TimeEntryRejected).
+			Count(&rejected).Error; err != nil {
+			return err
+		}
+		if rejected > 0 {
+			return errors.New("timesheet contains rejected entries")
+		}
+
+		var pending []struct {
+			ProjectID  uint
+			EntryDate  time.Time
+			ArchivedAt *time.Time
+		}
+		if err := tx.Model(&TimeEntry{}).
+			Select("tasks.project_id, time_entries.entry_date, projects.archived_at").
+			Joins("JOIN tasks ON tasks.id = time_entries.task_id").
+			Joins("JOIN projects ON projects.id = tasks.project_id").
+			Where("time_entries.user_id = ? AND time_entries.entry_date >= ? AND time_entries.entry_date < ?", sheet.UserID, start, start.AddDate(0, 0, 7)).
+			Where("time_entries.approved_at IS NULL").
+			Scan(&pending).Error; err != nil {
+			return err
+		}
+		for _, entry := range pending {
+			if entry.ArchivedAt != nil {
+				return ErrProjectArchived
+			}
+			if err := ensurePeriodOpen(tx, entry.ProjectID, entry.EntryDate); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now().UTC()
+		if err := weekEntries.Session(&gorm.Session{}).
+			Where("approved_at IS NULL").
+			Updates(map[string]interface{}{
+				"status":      TimeEntryApproved,
+				"approved_at": now,
+				"approved_by": approverID,
+			}).Error; err != nil {
+			return err
+		}
+
+		sheet.Status = TimesheetApproved
+		sheet.ApprovedAt = &now
+		sheet.ApprovedBy = &approverID
+		return tx.Save(&sheet).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sheet, nil
+}
+
+// Reject sends a submitted timesheet back to draft with a reason.
+func (s *TimesheetService) Reject(ctx context.Context, id uint, reviewerID uint, reason string) (*Timesheet, error) {
+	if reviewerID == 0 {
+		return nil, errors.New("reviewer is required")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	var sheet Timesheet
+	if err := s.db.WithContext(ctx).First(&sheet, id).Error; err != nil {
+		return nil, err
+	}
+	if sheet.Status != TimesheetSubmitted {
+		return nil, errors.New("only submitted timesheets can be rejected")
+	}
+	now := time.Now().UTC()
+	sheet.Status = TimesheetDraft
+	sheet.SubmittedAt = nil
+	sheet.RejectedAt = &now
+	sheet.RejectedBy = &reviewerID
+	sheet.RejectionReason = reason
+	if err := s.db.WithContext(ctx).Save(&sheet).Error; err != nil {
+		return nil, err
+	}
+	return &sheet, nil
+}
+
+func (s *TimesheetService) GetTimesheet(ctx context.Context, id uint) (*Timesheet, error) {
+	var sheet Timesheet
+	if err := s.db.WithContext(ctx).First(&sheet, id).Error; err != nil {
+		return nil, err
+	}
+	return &sheet, nil
+}
+
+func (s *TimesheetService) ListTimesheets(ctx context.Context, filter TimesheetFilter) (TimesheetsPage, error) {
+	filter = sanitizeTimesheetFilter(filter)
+
+	tx := s.db.WithContext(ctx).Model(&Timesheet{})
+	if filter.UserID != nil {
+		tx = tx.Where("user_id = ?", *filter.UserID)
+	}
+	if len(filter.Status) > 0 {
+		tx = tx.Where("status IN ?", filter.Status)
+	}
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return TimesheetsPage{}, err
+	}
+
+	offset := (filter.Page - 1) * filter.PageSize
+	var items []Timesheet
+	if err := tx.Order("year DESC, week DESC, id DESC").
+		Limit(filter.PageSize).
+		Offset(offset).
+		Find(&items).Error; err != nil {
+		return TimesheetsPage{}, err
+	}
+
+	return TimesheetsPage{Items: items, Total: total}, nil
+}
+
+// ParseISOWeek parses values such as "2025-W07".
+func ParseISOWeek(value string) (int, int, error) {
+	var year, week int
+	if _, err := fmt.Sscanf(strings.ToUpper(strings.TrimSpace(value)), "%d-W%d", &year, &week); err != nil {
+		return 0, 0, fmt.Errorf("invalid ISO week %q", value)
+	}
+	if _, err := isoWeekStart(year, week); err != nil {
+		return 0, 0, err
+	}
+	return year, week, nil
+}
+
+func saveTimesheetCell(ctx context.Context, tx *gorm.DB, timeSvc *TimeEntryService, userID uint, weekStart time.Time, cell TimesheetCellInput) error {
+	if cell.TaskID == 0 {
+		return errors.New("task is required")
+	}
+	if cell.Hours < 0 {
+		return errors.New("hours cannot be negative")
+	}
+	day := truncateDay(cell.Date)
+	if day.Before(weekStart) || !day.Before(weekStart.AddDate(0, 0, 7)) {
+		return errors.New("date is outside of the timesheet week")
+	}
+
+	var existing []TimeEntry
+	if err := tx.Where("user_id = ? AND task_id = ? AND entry_date >= ? AND entry_date < ?",
+		userID, cell.TaskID, day, day.AddDate(0, 0, 1)).
+		Order("id ASC").
+		Find(&existing).Error; err != nil {
+		return err
+	}
+	for _, entry := range existing {
+		if entry.ApprovedAt != nil {
+			return errors.New("cell contains approved entries")
+		}
+	}
+
+	if cell.Hours == 0 {
+		for _, entry := range existing {
+			if err := timeSvc.DeleteEntry(ctx, entry.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(existing) == 0 {
+		_, err := timeSvc.LogTime(ctx, TimeEntryInput{
+			TaskID:    cell.TaskID,
+			UserID:    userID,
+			EntryDate: cell.Date,
+			Hours:     cell.Hours,
+			Notes:     cell.Notes,
+		})
+		return err
+	}
+
+	// a célula fica com um único lançamento; os demais são removidos antes
+	// da edição para não contarem nos limites de horas e de orçamento
+	keep := existing[0]
+	for _, entry := range existing {
+		if entry.Status != TimeEntryRejected {
+			keep = entry
+			break
+		}
+	}
+	for _, entry := range existing {
+		if entry.ID == keep.ID {
+			continue
+		}
+		if err := timeSvc.DeleteEntry(ctx, entry.ID); err != nil {
+			return err
+		}
+	}
+
+	notes := cell.Notes
+	if notes == "" {
+		notes = keep.Notes
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, keep.ID, TimeEntryUpdateInput{
+		EntryDate: keep.EntryDate,
+		Hours:     cell.Hours,
+		Notes:     notes,
+	}); err != nil {
+		return err
+	}
+	// regravar uma célula rejeitada a devolve para aprovação
+	if keep.Status == TimeEntryRejected {
+		if _, err := timeSvc.ResubmitEntry(ctx, keep.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildTimesheetWeek(sheet Timesheet, start time.Time, entries []TimeEntry) *TimesheetWeek {
+	out := &TimesheetWeek{Timesheet: sheet, Entries: entries}
+	for i := 0; i < 7; i++ {
+		out.Days = append(out.Days, start.AddDate(0, 0, i))
+	}
+
+	rowIndex := map[uint]int{}
+	for _, entry := range entries {
+		idx, ok := rowIndex[entry.TaskID]
+		if !ok {
+			idx = len(out.Rows)
+			rowIndex[entry.TaskID] = idx
+			out.Rows = append(out.Rows, TimesheetRow{TaskID: entry.TaskID, Hours: make([]float64, 7)})
+		}
+		day := int(truncateDay(entry.EntryDate).Sub(start).Hours() / 24)
+		if day < 0 || day > 6 {
+			continue
+		}
+		out.Rows[idx].Hours[day] += entry.Hours
+		out.Rows[idx].Total += entry.Hours
+		out.Total += entry.Hours
+	}
+	return out
+}
+
+func findTimesheet(tx *gorm.DB, userID uint, year, week int) (*Timesheet, error) {
+	var sheet Timesheet
+	err := tx.Where("user_id = ? AND year = ? AND week = ?", userID, year, week).First(&sheet).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sheet, nil
+}
+
+// ensureTimesheetOpen rejects changes to entries dated inside a submitted or
+// approved week of the user.
+func ensureTimesheetOpen(tx *gorm.DB, userID uint, date time.Time) error {
+	year, week := date.UTC().ISOWeek()
+	var count int64
+	if err := tx.Model(&Timesheet{}).
+		Where("user_id = ? AND year = ? AND week = ? AND status <> ?", userID, year, week, TimesheetDraft).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrTimesheetLocked
+	}
+	return nil
+}
+
+// reopenTimesheetWeek moves the user's submitted or approved week holding
+// date back to draft, recording who sent it back and why, as Reject does.
+func reopenTimesheetWeek(tx *gorm.DB, userID uint, date time.Time, reviewerID uint, reason string) error {
+	year, week := date.UTC().ISOWeek()
+	return tx.Model(&Timesheet{}).
+		Where("user_id = ? AND year = ? AND week = ? AND status <> ?", userID, year, week, TimesheetDraft).
+		Updates(map[string]interface{}{
+			"status":           TimesheetDraft,
+			"submitted_at":     nil,
+			"approved_at":      nil,
+			"approved_by":      nil,
+			"rejected_at":      time.Now().UTC(),
+			"rejected_by":      reviewerID,
+			"rejection_reason": reason,
+		}).Error
+}
+
+func isoWeekStart(year, week int) (time.Time, error) {
+	if week < 1 || week > 53 {
+		return time.Time{}, fmt.Errorf("invalid ISO week %d", week)
+	}
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	start := jan4.AddDate(0, 0, -offset+(week-1)*7)
+	if y, w := start.ISOWeek(); y != year || w != week {
+		return time.Time{}, fmt.Errorf("year %d has no ISO week %d", year, week)
+	}
+	return start, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func sanitizeTimesheetFilter(filter TimesheetFilter) TimesheetFilter {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	return filter
+}
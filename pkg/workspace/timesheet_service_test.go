@@ -0,0 +1,330 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimesheetService_SaveSubmitAndApprove(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	sheetSvc := NewTimesheetService(db, timeSvc)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:        "Projeto",
+		ClientName:  "Cliente",
+		Description: "Desc",
+		StartDate:   time.Now().UTC().AddDate(0, 0, -60),
+		OwnerID:     1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{
+		ProjectID:  project.ID,
+		Title:      "Task",
+		AssigneeID: 7,
+	})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	year, week := time.Now().UTC().AddDate(0, 0, -14).ISOWeek()
+	monday, err := isoWeekStart(year, week)
+	if err != nil {
+		t.Fatalf("week start: %v", err)
+	}
+
+	grid, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.Add(9 * time.Hour), Hours: 8},
+		{TaskID: task.ID, Date: monday.AddDate(0, 0, 1).Add(9 * time.Hour), Hours: 6},
+	})
+	if err != nil {
+		t.Fatalf("save week: %v", err)
+	}
+	if len(grid.Rows) != 1 || grid.Total != 14 {
+		t.Fatalf("unexpected grid: %+v", grid)
+	}
+
+	// Saving the same cell again replaces its hours instead of adding an entry.
+	grid, err = sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.Add(10 * time.Hour), Hours: 4},
+	})
+	if err != nil {
+		t.Fatalf("save week again: %v", err)
+	}
+	if grid.Rows[0].Hours[0] != 4 || len(grid.Entries) != 2 {
+		t.Fatalf("expected monday cell replaced, got %+v", grid.Rows[0])
+	}
+
+	if _, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.AddDate(0, 0, 8), Hours: 1},
+	}); err == nil {
+		t.Fatal("expected error for cell outside week")
+	}
+
+	sheet, err := sheetSvc.Submit(ctx, 7, year, week)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if sheet.Status != TimesheetSubmitted {
+		t.Fatalf("expected submitted, got %s", sheet.Status)
+	}
+
+	_, err = timeSvc.LogTime(ctx, TimeEntryInput{
+		TaskID:    task.ID,
+		UserID:    7,
+		EntryDate: monday.AddDate(0, 0, 2).Add(9 * time.Hour),
+		Hours:     1,
+	})
+	if !errors.Is(err, ErrTimesheetLocked) {
+		t.Fatalf("expected locked week error, got %v", err)
+	}
+	_, err = timeSvc.UpdateEntry(ctx, grid.Entries[0].ID, TimeEntryUpdateInput{
+		EntryDate: grid.Entries[0].EntryDate,
+		Hours:     2,
+	})
+	if !errors.Is(err, ErrTimesheetLocked) {
+		t.Fatalf("expected locked week error on update, got %v", err)
+	}
+
+	rejected, err := sheetSvc.Reject(ctx, sheet.ID, 1, "faltou sexta")
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if rejected.Status != TimesheetDraft || rejected.RejectionReason != "faltou sexta" {
+		t.Fatalf("unexpected rejected timesheet: %+v", rejected)
+	}
+	if _, err := sheetSvc.Submit(ctx, 7, year, week); err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+
+	approved, err := sheetSvc.Approve(ctx, sheet.ID, 1)
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if approved.Status != TimesheetApproved {
+		t.Fatalf("expected approved, got %s", approved.Status)
+	}
+
+	page, err := timeSvc.ListEntries(ctx, TimeEntryFilter{UserID: ptrUint(7), Approved: ptrBool(true)})
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("expected all week entries approved, got %d", page.Total)
+	}
+}
+
+func TestTimesheetService_ZeroCellRespectsClosedPeriod(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	sheetSvc := NewTimesheetService(db, timeSvc)
+	periodSvc := NewPeriodLockService(db)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Projeto",
+		ClientName: "Cliente",
+		StartDate:  time.Now().UTC().AddDate(0, 0, -60),
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Task", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	year, week := time.Now().UTC().AddDate(0, 0, -14).ISOWeek()
+	monday, err := isoWeekStart(year, week)
+	if err != nil {
+		t.Fatalf("week start: %v", err)
+	}
+	if _, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.Add(9 * time.Hour), Hours: 8},
+	}); err != nil {
+		t.Fatalf("save week: %v", err)
+	}
+	if _, err := periodSvc.ClosePeriod(ctx, PeriodCloseInput{
+		ProjectID:   &project.ID,
+		PeriodStart: monday,
+		PeriodEnd:   monday.AddDate(0, 0, 6),
+		ClosedBy:    1,
+	}); err != nil {
+		t.Fatalf("close period: %v", err)
+	}
+
+	if _, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.Add(9 * time.Hour), Hours: 0},
+	}); !errors.Is(err, ErrPeriodClosed) {
+		t.Fatalf("expected ErrPeriodClosed clearing a cell, got %v", err)
+	}
+	grid, err := sheetSvc.GetWeek(ctx, 7, year, week)
+	if err != nil {
+		t.Fatalf("get week: %v", err)
+	}
+	if grid.Total != 8 {
+		t.Fatalf("expected the entry of the closed period to be kept, got %+v", grid)
+	}
+}
+
+func TestTimesheetService_CollapsesDuplicateCell(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db).WithDefaultPolicy(HourPolicy{MaxDailyHours: 10})
+	sheetSvc := NewTimesheetService(db, timeSvc)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{
+		Name:       "Projeto",
+		ClientName: "Cliente",
+		StartDate:  time.Now().UTC().AddDate(0, 0, -60),
+		OwnerID:    1,
+	})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Task", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	year, week := time.Now().UTC().AddDate(0, 0, -14).ISOWeek()
+	monday, err := isoWeekStart(year, week)
+	if err != nil {
+		t.Fatalf("week start: %v", err)
+	}
+	for _, hour := range []int{9, 14} {
+		if _, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: monday.Add(time.Duration(hour) * time.Hour), Hours: 5}); err != nil {
+			t.Fatalf("log time: %v", err)
+		}
+	}
+	rejected, err := timeSvc.LogTime(ctx, TimeEntryInput{TaskID: task.ID, UserID: 7, EntryDate: monday.AddDate(0, 0, 1).Add(9 * time.Hour), Hours: 3})
+	if err != nil {
+		t.Fatalf("log time: %v", err)
+	}
+	if _, err := timeSvc.RejectEntry(ctx, rejected.ID, 1, "tarefa errada"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+
+	// 5h + 5h passam a 8h: as duplicatas não contam contra o limite diário
+	grid, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday, Hours: 8},
+		{TaskID: task.ID, Date: monday.AddDate(0, 0, 1), Hours: 4},
+	})
+	if err != nil {
+		t.Fatalf("save week: %v", err)
+	}
+	if len(grid.Entries) != 2 || grid.Rows[0].Hours[0] != 8 {
+		t.Fatalf("expected one entry per cell, got %+v", grid)
+	}
+	resubmitted, err := timeSvc.GetEntry(ctx, rejected.ID)
+	if err != nil {
+		t.Fatalf("get entry: %v", err)
+	}
+	if resubmitted.Status != TimeEntryPending || resubmitted.Hours != 4 {
+		t.Fatalf("expected the rejected cell to go back to approval, got %+v", resubmitted)
+	}
+}
+
+func TestTimesheetService_ReviewReopensLockedWeek(t *testing.T) {
+	db := newWorkspaceTestDB(t)
+	projectSvc := NewProjectService(db)
+	taskSvc := NewTaskService(db)
+	timeSvc := NewTimeEntryService(db)
+	sheetSvc := NewTimesheetService(db, timeSvc)
+	ctx := context.Background()
+
+	project, err := projectSvc.CreateProject(ctx, ProjectInput{Name: "Projeto", ClientName: "Cliente", StartDate: time.Now().UTC().AddDate(0, 0, -60), OwnerID: 1})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	task, err := taskSvc.CreateTask(ctx, TaskInput{ProjectID: project.ID, Title: "Task", AssigneeID: 7})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	year, week := time.Now().UTC().AddDate(0, 0, -21).ISOWeek()
+	monday, err := isoWeekStart(year, week)
+	if err != nil {
+		t.Fatalf("week start: %v", err)
+	}
+	grid, err := sheetSvc.SaveWeek(ctx, 7, year, week, []TimesheetCellInput{
+		{TaskID: task.ID, Date: monday.Add(9 * time.Hour), Hours: 8},
+		{TaskID: task.ID, Date: monday.AddDate(0, 0, 1).Add(9 * time.Hour), Hours: 6},
+	})
+	if err != nil {
+		t.Fatalf("save week: %v", err)
+	}
+	rejected, reopened := grid.Entries[0], grid.Entries[1]
+	sheet, err := sheetSvc.Submit(ctx, 7, year, week)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	// rejeitar um lançamento de semana submetida devolve a semana ao autor
+	if _, err := timeSvc.RejectEntry(ctx, rejected.ID, 1, "horas a mais"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if sheet, err = sheetSvc.GetTimesheet(ctx, sheet.ID); err != nil || sheet.Status != TimesheetDraft || sheet.RejectionReason != "horas a mais" {
+		t.Fatalf("expected the week back in draft, got %+v (%v)", sheet, err)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, rejected.ID, TimeEntryUpdateInput{EntryDate: rejected.EntryDate, Hours: 7}); err != nil {
+		t.Fatalf("correct rejected entry: %v", err)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, rejected.ID); err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+	if _, err := sheetSvc.Submit(ctx, 7, year, week); err != nil {
+		t.Fatalf("submit again: %v", err)
+	}
+	if _, err := sheetSvc.Approve(ctx, sheet.ID, 1); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+
+	// reabrir um lançamento de semana aprovada também permite corrigi-lo
+	if _, err := timeSvc.ReopenEntry(ctx, reopened.ID, 1, "cliente contestou"); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if sheet, err = sheetSvc.GetTimesheet(ctx, sheet.ID); err != nil || sheet.Status != TimesheetDraft || sheet.ApprovedAt != nil {
+		t.Fatalf("expected the approved week back in draft, got %+v (%v)", sheet, err)
+	}
+	if _, err := timeSvc.UpdateEntry(ctx, reopened.ID, TimeEntryUpdateInput{EntryDate: reopened.EntryDate, Hours: 5}); err != nil {
+		t.Fatalf("correct reopened entry: %v", err)
+	}
+
+	// lançamentos rejeitados não voltam à fila de uma semana travada
+	if _, err := timeSvc.RejectEntry(ctx, reopened.ID, 1, "ainda errado"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := sheetSvc.Submit(ctx, 7, year, week); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if _, err := timeSvc.ResubmitEntry(ctx, reopened.ID); !errors.Is(err, ErrTimesheetLocked) {
+		t.Fatalf("expected ErrTimesheetLocked on resubmit, got %v", err)
+	}
+}
+
+func TestParseISOWeek(t *testing.T) {
+	year, week, err := ParseISOWeek("2025-w07")
+	if err != nil || year != 2025 || week != 7 {
+		t.Fatalf("unexpected parse result %d %d %v", year, week, err)
+	}
+	if _, _, err := ParseISOWeek("2021-W53"); err == nil {
+		t.Fatal("expected error for week 53 in a 52-week year")
+	}
+	if _, _, err := ParseISOWeek("semana 7"); err == nil {
+		t.Fatal("expected error for malformed week")
+	}
+}
+
+func ptrBool(v bool) *bool { return &v }